@@ -0,0 +1,260 @@
+// Package batch inserts rows with GORM v1 in batches, one multi-row INSERT
+// statement per batch, as CreateInBatches does in GORM v2. The generated
+// CreateSet and PatchSet handlers use it when generated for GORM v1
+package batch
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Create inserts the elements of the slice value points to, structs or
+// pointers to structs, in batches of batchSize rows (all of them if less
+// than 1). Like the create callbacks of GORM v1 it calls the BeforeSave,
+// BeforeCreate, AfterCreate and AfterSave methods of the rows, sets their
+// blank CreatedAt and UpdatedAt fields, appends the gorm:insert_option of db
+// to the statements, sets the primary keys the database generated and reads
+// back the blank fields having a default value. Associations are not saved
+func Create(db *gorm.DB, value interface{}, batchSize int) error {
+	if db.Error != nil {
+		return db.Error
+	}
+	rows := reflect.Indirect(reflect.ValueOf(value))
+	if rows.Kind() != reflect.Slice {
+		return fmt.Errorf("batch: %T is not a pointer to a slice", value)
+	}
+	if batchSize < 1 {
+		batchSize = rows.Len()
+	}
+	for start := 0; start < rows.Len(); start += batchSize {
+		end := start + batchSize
+		if end > rows.Len() {
+			end = rows.Len()
+		}
+		if err := createBatch(db, rows.Slice(start, end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createBatch inserts the rows, with one statement per run of rows inserting
+// the same columns
+func createBatch(db *gorm.DB, rows reflect.Value) error {
+	scopes := make([]*gorm.Scope, rows.Len())
+	for i := range scopes {
+		row := rows.Index(i)
+		if row.Kind() != reflect.Ptr {
+			row = row.Addr()
+		}
+		scopes[i] = db.NewScope(row.Interface())
+		if err := callMethods(scopes[i], "BeforeSave", "BeforeCreate"); err != nil {
+			return err
+		}
+		now := gorm.NowFunc()
+		for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+			if field, ok := scopes[i].FieldByName(name); ok && field.IsBlank {
+				if err := field.Set(now); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for start := 0; start < len(scopes); {
+		columns, defaults := insertColumns(scopes[start])
+		end := start + 1
+		for ; end < len(scopes) && len(columns) > 0; end++ {
+			if next, _ := insertColumns(scopes[end]); !equal(next, columns) {
+				break
+			}
+		}
+		if err := insert(db, scopes[start:end], columns); err != nil {
+			return err
+		}
+		if err := reload(db, scopes[start:end], defaults); err != nil {
+			return err
+		}
+		start = end
+	}
+	for _, scope := range scopes {
+		if err := callMethods(scope, "AfterCreate", "AfterSave"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callMethods calls the hook methods of the row of scope
+func callMethods(scope *gorm.Scope, methods ...string) error {
+	for _, method := range methods {
+		if scope.CallMethod(method); scope.HasError() {
+			return scope.DB().Error
+		}
+	}
+	return nil
+}
+
+// insertColumns returns the columns inserted for the row of scope, leaving out
+// a blank primary key and the blank fields having a default value, which
+// are returned as defaults
+func insertColumns(scope *gorm.Scope) (columns, defaults []string) {
+	for _, field := range scope.Fields() {
+		if !field.IsNormal || field.IsIgnored {
+			continue
+		}
+		switch {
+		case field.IsBlank && field.HasDefaultValue:
+			defaults = append(defaults, field.DBName)
+		case field.IsBlank && field.IsPrimaryKey:
+		default:
+			columns = append(columns, field.DBName)
+		}
+	}
+	return columns, defaults
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// insert inserts the rows of scopes, which all have the columns, and sets the
+// primary keys the database generated
+func insert(db *gorm.DB, scopes []*gorm.Scope, columns []string) error {
+	stmt := db.NewScope(scopes[0].Value)
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = stmt.Quote(column)
+	}
+	var values []string
+	for _, scope := range scopes {
+		vars := make([]string, len(columns))
+		for i, column := range columns {
+			field, _ := scope.FieldByName(column)
+			vars[i] = stmt.AddToVars(field.Field.Interface())
+		}
+		values = append(values, "("+strings.Join(vars, ",")+")")
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", stmt.QuotedTableName(), strings.Join(quoted, ","), strings.Join(values, ","))
+	if len(columns) == 0 {
+		// only one row at a time inserts the defaults
+		query = fmt.Sprintf("INSERT INTO %s %s", stmt.QuotedTableName(), stmt.Dialect().DefaultValueStr())
+	}
+	if option, ok := db.Get("gorm:insert_option"); ok {
+		query += " " + fmt.Sprint(option)
+	}
+	primary := stmt.PrimaryField()
+	if primary == nil || contains(columns, primary.DBName) {
+		_, err := stmt.SQLDB().Exec(stmt.Raw(query).SQL, stmt.SQLVars...)
+		return err
+	}
+	if returning := stmt.Dialect().LastInsertIDReturningSuffix(stmt.QuotedTableName(), stmt.Quote(primary.DBName)); returning != "" {
+		rows, err := stmt.SQLDB().Query(stmt.Raw(query+" "+returning).SQL, stmt.SQLVars...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		// the rows are returned in the order of the VALUES
+		for i := 0; rows.Next() && i < len(scopes); i++ {
+			if err := rows.Scan(scopes[i].PrimaryField().Field.Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}
+	result, err := stmt.SQLDB().Exec(stmt.Raw(query).SQL, stmt.SQLVars...)
+	if err != nil {
+		return err
+	}
+	return setInsertIDs(stmt, scopes, result)
+}
+
+// setInsertIDs sets the integer primary keys of the rows from the last insert
+// id, the one of the first row on MySQL and of the last row on SQLite
+func setInsertIDs(stmt *gorm.Scope, scopes []*gorm.Scope, result sql.Result) error {
+	switch stmt.PrimaryField().Field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	if stmt.Dialect().GetName() == "sqlite3" {
+		id -= int64(len(scopes) - 1)
+	}
+	for i, scope := range scopes {
+		if err := scope.PrimaryField().Set(id + int64(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reload reads back the columns the database set the default values of
+func reload(db *gorm.DB, scopes []*gorm.Scope, columns []string) error {
+	primary := scopes[0].PrimaryField()
+	if len(columns) == 0 || primary == nil {
+		return nil
+	}
+	keys := make([]interface{}, len(scopes))
+	byKey := make(map[string]*gorm.Scope, len(scopes))
+	for i, scope := range scopes {
+		keys[i] = scope.PrimaryKeyValue()
+		byKey[keyString(keys[i])] = scope
+	}
+	rows, err := db.New().Table(scopes[0].TableName()).Select(append([]string{primary.DBName}, columns...)).
+		Where(fmt.Sprintf("%s IN (?)", scopes[0].Quote(primary.DBName)), keys).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		dest := make([]interface{}, 1+len(columns))
+		dest[0] = reflect.New(primary.Field.Type()).Interface()
+		for i, column := range columns {
+			field, _ := scopes[0].FieldByName(column)
+			dest[1+i] = reflect.New(field.Field.Type()).Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		scope, ok := byKey[keyString(reflect.ValueOf(dest[0]).Elem().Interface())]
+		if !ok {
+			continue
+		}
+		for i, column := range columns {
+			field, _ := scope.FieldByName(column)
+			field.Field.Set(reflect.ValueOf(dest[1+i]).Elem())
+		}
+	}
+	return rows.Err()
+}
+
+// keyString formats a primary key, which may be a pointer or a slice, so
+// that the keys of a row and of its scanned copy are equal
+func keyString(key interface{}) string {
+	return fmt.Sprint(reflect.Indirect(reflect.ValueOf(key)).Interface())
+}
+
+func contains(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type point struct {
+	ID        uint32
+	X         int32
+	Y         int32 `gorm:"default:7"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Hooks     []string `gorm:"-"`
+}
+
+func (p *point) BeforeCreate() error {
+	p.Hooks = append(p.Hooks, "BeforeCreate")
+	return nil
+}
+
+func (p *point) AfterSave() error {
+	p.Hooks = append(p.Hooks, "AfterSave")
+	return nil
+}
+
+var now = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestCreate(t *testing.T) {
+	defer func(nowFunc func() time.Time) { gorm.NowFunc = nowFunc }(gorm.NowFunc)
+	gorm.NowFunc = func() time.Time { return now }
+
+	for _, tc := range []struct {
+		dialect    string
+		option     string
+		batchSize  int
+		responses  []response
+		statements []string
+		ids        []uint32
+	}{
+		{
+			dialect:   "postgres",
+			batchSize: 2,
+			responses: []response{
+				{rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+				// the reloaded rows come in any order
+				{rows: [][]driver.Value{{int64(2), int64(7)}, {int64(1), int64(7)}}},
+				{rows: [][]driver.Value{{int64(3)}}},
+			},
+			statements: []string{
+				`INSERT INTO "points" ("x","created_at","updated_at") VALUES ($1,$2,$3),($4,$5,$6) RETURNING "points"."id"`,
+				`SELECT id, y FROM "points"  WHERE ("id" IN ($1,$2))`,
+				`INSERT INTO "points" ("x","y","created_at","updated_at") VALUES ($1,$2,$3,$4) RETURNING "points"."id"`,
+			},
+			ids: []uint32{1, 2, 3},
+		},
+		{
+			dialect:   "mysql",
+			option:    "ON DUPLICATE KEY UPDATE x = VALUES(x)",
+			responses: []response{{lastInsertID: 10}, {rows: [][]driver.Value{{int64(10), int64(7)}, {int64(11), int64(7)}}}, {lastInsertID: 12}},
+			statements: []string{
+				"INSERT INTO `points` (`x`,`created_at`,`updated_at`) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE x = VALUES(x)",
+				"SELECT id, y FROM `points`  WHERE (`id` IN (?,?))",
+				"INSERT INTO `points` (`x`,`y`,`created_at`,`updated_at`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE x = VALUES(x)",
+			},
+			ids: []uint32{10, 11, 12},
+		},
+		{
+			dialect: "sqlite3",
+			// the last insert id is the one of the last row
+			responses: []response{{lastInsertID: 2}, {rows: [][]driver.Value{{int64(1), int64(7)}, {int64(2), int64(7)}}}, {lastInsertID: 3}},
+			statements: []string{
+				`INSERT INTO "points" ("x","created_at","updated_at") VALUES (?,?,?),(?,?,?)`,
+				`SELECT id, y FROM "points"  WHERE ("id" IN (?,?))`,
+				`INSERT INTO "points" ("x","y","created_at","updated_at") VALUES (?,?,?,?)`,
+			},
+			ids: []uint32{1, 2, 3},
+		},
+	} {
+		t.Run(tc.dialect, func(t *testing.T) {
+			conn := &fakeConn{responses: tc.responses}
+			db, err := gorm.Open(tc.dialect, sql.OpenDB(conn))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.option != "" {
+				db = db.Set("gorm:insert_option", tc.option)
+			}
+			points := []point{{X: 1}, {X: 2}, {X: 3, Y: 5}}
+			if err := Create(db, &points, tc.batchSize); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(conn.statements, tc.statements) {
+				t.Errorf("executed\n%q\nwant\n%q", conn.statements, tc.statements)
+			}
+			for i, p := range points {
+				want := point{ID: tc.ids[i], X: int32(i + 1), Y: 7, CreatedAt: now, UpdatedAt: now, Hooks: []string{"BeforeCreate", "AfterSave"}}
+				if i == 2 {
+					want.Y = 5
+				}
+				if !reflect.DeepEqual(p, want) {
+					t.Errorf("created %+v; want %+v", p, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateWithKeys(t *testing.T) {
+	conn := &fakeConn{}
+	db, err := gorm.Open("postgres", sql.OpenDB(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := []*point{{ID: 4, X: 1, Y: 1}, {ID: 5, X: 2, Y: 2}}
+	if err := Create(db, &points, 0); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`INSERT INTO "points" ("id","x","y","created_at","updated_at") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`}
+	if !reflect.DeepEqual(conn.statements, want) {
+		t.Errorf("executed %q; want %q", conn.statements, want)
+	}
+	if err := Create(db, points[0], 0); err == nil {
+		t.Error("expected error creating a struct")
+	}
+}
+
+// response is the result of a statement run by fakeConn
+type response struct {
+	rows         [][]driver.Value
+	lastInsertID int64
+}
+
+// fakeConn is a database connection recording the statements it runs and
+// answering them with the responses, in order
+type fakeConn struct {
+	statements []string
+	responses  []response
+}
+
+func (c *fakeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *fakeConn) Driver() driver.Driver                        { return nil }
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error)    { return &fakeStmt{c, query}, nil }
+func (c *fakeConn) Close() error                                 { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+func (c *fakeConn) respond(query string) response {
+	c.statements = append(c.statements, query)
+	if len(c.responses) == 0 {
+		return response{}
+	}
+	r := c.responses[0]
+	c.responses = c.responses[1:]
+	return r
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.conn.respond(s.query).rows}, nil
+}
+
+// ExecContext returns the last insert id of the response
+func (s *fakeStmt) ExecContext(context.Context, []driver.NamedValue) (driver.Result, error) {
+	return result(s.conn.respond(s.query).lastInsertID), nil
+}
+
+type result int64
+
+func (r result) LastInsertId() (int64, error) { return int64(r), nil }
+func (r result) RowsAffected() (int64, error) { return 0, nil }
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return []string{"id"}
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -1,6 +1,11 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 var EmptyIdError = errors.New("id is empty")
 
@@ -8,4 +13,166 @@ var NilArgumentError = errors.New("argument is nil")
 
 var NoTransactionError = errors.New("transaction is not opened")
 
+var ConflictError = errors.New("object was modified concurrently, version mismatch")
+
+// InvalidFieldError is wrapped by the errors of List requests filtering or
+// sorting by a field the type doesn't allow
+var InvalidFieldError = errors.New("field cannot be used in queries")
+
 var BadRepeatedFieldMaskTpl = "unexpected fieldmask count %d for objects count %d"
+
+// ItemError is the error of the object at Index of a bulk operation
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("object %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// SetError lists the objects a bulk operation failed for, none of the
+// objects is written when it is returned
+type SetError []*ItemError
+
+func (e SetError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, itemErr := range e {
+		msgs = append(msgs, itemErr.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the error of the first failed object, so that errors.Is
+// tells why a single object failed
+func (e SetError) Unwrap() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0]
+}
+
+// violation identifies a kind of constraint violation by the codes of the DB
+// drivers, read through their exported fields and methods so that no driver
+// has to be imported
+type violation struct {
+	// sqlState is the SQLSTATE of lib/pq and pgx errors on Postgres
+	sqlState string
+	// mysqlNumbers are the error numbers of go-sql-driver/mysql errors
+	mysqlNumbers []uint64
+	// sqliteCodes are the extended result codes of mattn/go-sqlite3 and
+	// modernc.org/sqlite errors
+	sqliteCodes []int64
+	// sqliteMessage is in the message of the SQLite errors of other drivers
+	sqliteMessage string
+	// gormMessage is the message of the gorm.io/gorm error TranslateError
+	// returns
+	gormMessage string
+}
+
+var (
+	uniqueViolation = violation{
+		sqlState:      "23505",
+		mysqlNumbers:  []uint64{1062},
+		sqliteCodes:   []int64{2067, 1555}, // SQLITE_CONSTRAINT_UNIQUE and SQLITE_CONSTRAINT_PRIMARYKEY
+		sqliteMessage: "UNIQUE constraint failed",
+		gormMessage:   "duplicated key not allowed",
+	}
+	foreignKeyViolation = violation{
+		sqlState:      "23503",
+		mysqlNumbers:  []uint64{1451, 1452}, // deleting a referenced row and referencing a missing one
+		sqliteCodes:   []int64{787},         // SQLITE_CONSTRAINT_FOREIGNKEY
+		sqliteMessage: "FOREIGN KEY constraint failed",
+		gormMessage:   "violates foreign key constraint",
+	}
+)
+
+// IsUniqueViolation tells whether err, or an error it wraps, reports a
+// duplicate value of a primary key or unique index on Postgres, MySQL or SQLite
+func IsUniqueViolation(err error) bool {
+	return uniqueViolation.matches(err)
+}
+
+// IsForeignKeyViolation tells whether err, or an error it wraps, reports a
+// foreign key constraint violation on Postgres, MySQL or SQLite
+func IsForeignKeyViolation(err error) bool {
+	return foreignKeyViolation.matches(err)
+}
+
+func (v violation) matches(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if code, ok := sqlState(err); ok {
+			return code == v.sqlState
+		}
+		if number, ok := errorField(err, "Number"); ok && number.Kind() == reflect.Uint16 {
+			return containsUint(v.mysqlNumbers, number.Uint())
+		}
+		if code, ok := sqliteCode(err); ok {
+			return containsInt(v.sqliteCodes, code)
+		}
+		if msg := err.Error(); msg == v.gormMessage || strings.Contains(msg, v.sqliteMessage) {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlState returns the SQLSTATE of a pgx error, or the Code of a lib/pq error
+func sqlState(err error) (string, bool) {
+	if e, ok := err.(interface{ SQLState() string }); ok {
+		return e.SQLState(), true
+	}
+	if code, ok := errorField(err, "Code"); ok && code.Kind() == reflect.String {
+		return code.String(), true
+	}
+	return "", false
+}
+
+// sqliteCode returns the extended result code of a mattn/go-sqlite3 error, or
+// the Code of a modernc.org/sqlite error
+func sqliteCode(err error) (int64, bool) {
+	if code, ok := errorField(err, "ExtendedCode"); ok && code.Kind() == reflect.Int {
+		return code.Int(), true
+	}
+	if e, ok := err.(interface{ Code() int }); ok {
+		return int64(e.Code()), true
+	}
+	return 0, false
+}
+
+// errorField returns the exported field of the struct err is or points to
+func errorField(err error, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if f, ok := v.Type().FieldByName(name); !ok || f.PkgPath != "" {
+		return reflect.Value{}, false
+	}
+	return v.FieldByName(name), true
+}
+
+func containsUint(values []uint64, value uint64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int64, value int64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
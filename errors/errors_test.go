@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// The error types below have the fields and methods of the driver errors
+
+// pqError is a github.com/lib/pq *Error
+type pqError struct {
+	Severity string
+	Code     pqErrorCode
+	Message  string
+}
+
+type pqErrorCode string
+
+func (e *pqError) Error() string { return "pq: " + e.Message }
+
+// pgError is a github.com/jackc/pgx/v5/pgconn *PgError
+type pgError struct {
+	Code    string
+	Message string
+}
+
+func (e *pgError) Error() string    { return e.Message + " (SQLSTATE " + e.Code + ")" }
+func (e *pgError) SQLState() string { return e.Code }
+
+// mysqlError is a github.com/go-sql-driver/mysql *MySQLError
+type mysqlError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+// sqlite3Error is a github.com/mattn/go-sqlite3 Error
+type sqlite3Error struct {
+	Code         sqlite3ErrNo
+	ExtendedCode sqlite3ErrNoExtended
+	err          string
+}
+
+type sqlite3ErrNo int
+
+type sqlite3ErrNoExtended int
+
+func (e sqlite3Error) Error() string { return e.err }
+
+// moderncError is a modernc.org/sqlite *Error
+type moderncError struct {
+	msg  string
+	code int
+}
+
+func (e *moderncError) Error() string { return e.msg }
+func (e *moderncError) Code() int     { return e.code }
+
+func TestViolations(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		err        error
+		unique, fk bool
+	}{
+		{"nil", nil, false, false},
+		{"not found", errors.New("record not found"), false, false},
+		{"pq unique", &pqError{Code: "23505", Message: `duplicate key value violates unique constraint "idx_email"`}, true, false},
+		{"pq foreign key", &pqError{Code: "23503", Message: `insert or update on table "pets" violates foreign key constraint "fk_owner"`}, false, true},
+		{"pq not null", &pqError{Code: "23502", Message: `null value in column "name" violates not-null constraint`}, false, false},
+		{"pq unique with a misleading message", &pqError{Code: "23514", Message: "violates foreign key constraint, duplicated key not allowed"}, false, false},
+		{"pgx unique", &pgError{Code: "23505", Message: "duplicate key value violates unique constraint"}, true, false},
+		{"pgx foreign key", &pgError{Code: "23503", Message: "violates foreign key constraint"}, false, true},
+		{"mysql duplicate entry", &mysqlError{Number: 1062, Message: "Duplicate entry 'a' for key 'idx_email'"}, true, false},
+		{"mysql missing parent", &mysqlError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"}, false, true},
+		{"mysql referenced row", &mysqlError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"}, false, true},
+		{"mysql lock wait", &mysqlError{Number: 1205, Message: "Lock wait timeout exceeded"}, false, false},
+		{"sqlite3 unique", sqlite3Error{Code: 19, ExtendedCode: 2067, err: "UNIQUE constraint failed: contacts.email"}, true, false},
+		{"sqlite3 primary key", sqlite3Error{Code: 19, ExtendedCode: 1555, err: "UNIQUE constraint failed: contacts.id"}, true, false},
+		{"sqlite3 foreign key", sqlite3Error{Code: 19, ExtendedCode: 787, err: "FOREIGN KEY constraint failed"}, false, true},
+		{"sqlite3 not null", sqlite3Error{Code: 19, ExtendedCode: 1299, err: "NOT NULL constraint failed: contacts.name"}, false, false},
+		{"modernc unique", &moderncError{code: 2067, msg: "constraint failed: UNIQUE constraint failed: contacts.email (2067)"}, true, false},
+		{"sqlite message", errors.New("UNIQUE constraint failed: contacts.email"), true, false},
+		{"gorm duplicated key", errors.New("duplicated key not allowed"), true, false},
+		{"gorm foreign key", errors.New("violates foreign key constraint"), false, true},
+		{"wrapped", fmt.Errorf("creating contact: %w", &pqError{Code: "23505"}), true, false},
+		{"set error", SetError{{Index: 1, Err: &mysqlError{Number: 1062}}}, true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsUniqueViolation(tc.err); got != tc.unique {
+				t.Errorf("IsUniqueViolation = %v; want %v", got, tc.unique)
+			}
+			if got := IsForeignKeyViolation(tc.err); got != tc.fk {
+				t.Errorf("IsForeignKeyViolation = %v; want %v", got, tc.fk)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package fieldmap restricts the fields the generated List handlers filter
+// and sort by to the ones the message options allow, and translates the names
+// used by the clients to the fields of the ORM type
+package fieldmap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	gerrors "github.com/suutaku/protoc-gen-gorm/errors"
+)
+
+// Fields maps the names clients may use in queries to the names of the
+// fields of the ORM type
+type Fields map[string]string
+
+var stringSliceType = reflect.TypeOf([]string(nil))
+
+// MapFiltering checks that the conditions of filtering, a *query.Filtering,
+// only name allowed fields, and rewrites their field paths in place to the
+// names of the ORM fields
+func (fs Fields) MapFiltering(filtering interface{}) error {
+	return fs.mapFieldPaths(reflect.ValueOf(filtering))
+}
+
+// MapSortTag returns the name of the ORM field of a sort criteria tag, the
+// error wraps errors.InvalidFieldError if the field is not allowed
+func (fs Fields) MapSortTag(tag string) (string, error) {
+	name, ok := fs[tag]
+	if !ok {
+		return "", fmt.Errorf("%w, cannot sort by %q", gerrors.InvalidFieldError, tag)
+	}
+	return name, nil
+}
+
+// mapFieldPaths walks the conditions of a filter, which all have a
+// FieldPath, whatever the version of the query package is
+func (fs Fields) mapFieldPaths(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return fs.mapFieldPaths(v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			// skip the unexported fields, e.g. the protobuf internal state
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			field := v.Field(i)
+			if t.Field(i).Name == "FieldPath" && field.Type() == stringSliceType {
+				path := strings.Join(field.Interface().([]string), ".")
+				name, ok := fs[path]
+				if !ok {
+					return fmt.Errorf("%w, cannot filter by %q", gerrors.InvalidFieldError, path)
+				}
+				field.Set(reflect.ValueOf(strings.Split(name, ".")))
+				continue
+			}
+			if err := fs.mapFieldPaths(field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package fieldmap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gerrors "github.com/suutaku/protoc-gen-gorm/errors"
+)
+
+// the shape of query.Filtering, a tree of conditions in oneof wrappers
+type condition struct {
+	FieldPath []string
+	Value     string
+}
+
+type operator struct {
+	Left  isNode
+	Right isNode
+}
+
+type isNode interface{ isNode() }
+
+type leftCondition struct{ Condition *condition }
+
+type rightOperator struct{ Operator *operator }
+
+func (*leftCondition) isNode() {}
+
+func (*rightOperator) isNode() {}
+
+type filtering struct {
+	Root  isNode
+	state struct{ FieldPath []string }
+}
+
+var fields = Fields{"first_name": "FirstName", "firstName": "FirstName", "FirstName": "FirstName", "age": "Age", "Age": "Age"}
+
+func TestMapFiltering(t *testing.T) {
+	first, age := &condition{FieldPath: []string{"firstName"}}, &condition{FieldPath: []string{"age"}}
+	f := &filtering{Root: &rightOperator{&operator{Left: &leftCondition{first}, Right: &leftCondition{age}}}}
+	f.state.FieldPath = []string{"secret"}
+	if err := fields.MapFiltering(f); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := [][]string{first.FieldPath, age.FieldPath}, [][]string{{"FirstName"}, {"Age"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	// mapped names stay the same
+	if err := fields.MapFiltering(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := fields.MapFiltering((*filtering)(nil)); err != nil {
+		t.Errorf("unexpected error for a nil filter: %v", err)
+	}
+	f.Root = &leftCondition{&condition{FieldPath: []string{"password"}}}
+	if err := fields.MapFiltering(f); !errors.Is(err, gerrors.InvalidFieldError) {
+		t.Errorf("got %v; want InvalidFieldError", err)
+	}
+}
+
+func TestMapSortTag(t *testing.T) {
+	if got, err := fields.MapSortTag("first_name"); err != nil || got != "FirstName" {
+		t.Errorf("got %q, %v; want FirstName", got, err)
+	}
+	if _, err := fields.MapSortTag("password"); !errors.Is(err, gerrors.InvalidFieldError) {
+		t.Errorf("got %v; want InvalidFieldError", err)
+	}
+}
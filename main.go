@@ -11,6 +11,7 @@ func main() {
 	op := &plugin.OrmPlugin{}
 	response := command.GeneratePlugin(command.Read(), op, ".pb.gorm.go")
 	op.CleanFiles(response)
+	op.AddMigrationFiles(response)
 	command.Write(response)
 
 }
@@ -21,6 +21,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Encoding of a serialized message field
+type SerializeFormat int32
+
+const (
+	SerializeFormat_NONE SerializeFormat = 0
+	// protobuf JSON in a jsonb, json or text column
+	SerializeFormat_JSON SerializeFormat = 1
+	// protobuf wire format in a bytea or blob column
+	SerializeFormat_PROTO_BINARY SerializeFormat = 2
+)
+
+var SerializeFormat_name = map[int32]string{
+	0: "NONE",
+	1: "JSON",
+	2: "PROTO_BINARY",
+}
+
+var SerializeFormat_value = map[string]int32{
+	"NONE":         0,
+	"JSON":         1,
+	"PROTO_BINARY": 2,
+}
+
+func (x SerializeFormat) String() string {
+	return proto.EnumName(SerializeFormat_name, int32(x))
+}
+
+func (SerializeFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_69675b971f61ce8f, []int{0}
+}
+
 type GormFileOptions struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -52,13 +83,26 @@ func (m *GormFileOptions) XXX_DiscardUnknown() {
 var xxx_messageInfo_GormFileOptions proto.InternalMessageInfo
 
 type GormMessageOptions struct {
-	Ormable              bool          `protobuf:"varint,1,opt,name=ormable,proto3" json:"ormable,omitempty"`
-	Include              []*ExtraField `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
-	Table                string        `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
-	MultiAccount         bool          `protobuf:"varint,4,opt,name=multi_account,json=multiAccount,proto3" json:"multi_account,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Ormable      bool          `protobuf:"varint,1,opt,name=ormable,proto3" json:"ormable,omitempty"`
+	Include      []*ExtraField `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	Table        string        `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	MultiAccount bool          `protobuf:"varint,4,opt,name=multi_account,json=multiAccount,proto3" json:"multi_account,omitempty"`
+	// previous table name, used by the migration diff to rename the table
+	RenamedFrom string `protobuf:"bytes,5,opt,name=renamed_from,json=renamedFrom,proto3" json:"renamed_from,omitempty"`
+	// adds a DeletedAt column, Delete only marks the rows as deleted
+	SoftDelete bool `protobuf:"varint,6,opt,name=soft_delete,json=softDelete,proto3" json:"soft_delete,omitempty"`
+	// adds CreatedAt and UpdatedAt columns, or binds the Timestamp fields of
+	// the same names, which are set by the handlers
+	Timestamps bool `protobuf:"varint,7,opt,name=timestamps,proto3" json:"timestamps,omitempty"`
+	// integer field checked and incremented by the updates, a stale value makes
+	// them fail with errors.ConflictError
+	VersionField string `protobuf:"bytes,8,opt,name=version_field,json=versionField,proto3" json:"version_field,omitempty"`
+	// unique_index whose columns are the conflict target of DefaultUpsert, the
+	// primary key if empty
+	UpsertOn             string   `protobuf:"bytes,9,opt,name=upsert_on,json=upsertOn,proto3" json:"upsert_on,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GormMessageOptions) Reset()         { *m = GormMessageOptions{} }
@@ -113,6 +157,41 @@ func (m *GormMessageOptions) GetMultiAccount() bool {
 	return false
 }
 
+func (m *GormMessageOptions) GetRenamedFrom() string {
+	if m != nil {
+		return m.RenamedFrom
+	}
+	return ""
+}
+
+func (m *GormMessageOptions) GetSoftDelete() bool {
+	if m != nil {
+		return m.SoftDelete
+	}
+	return false
+}
+
+func (m *GormMessageOptions) GetTimestamps() bool {
+	if m != nil {
+		return m.Timestamps
+	}
+	return false
+}
+
+func (m *GormMessageOptions) GetVersionField() string {
+	if m != nil {
+		return m.VersionField
+	}
+	return ""
+}
+
+func (m *GormMessageOptions) GetUpsertOn() string {
+	if m != nil {
+		return m.UpsertOn
+	}
+	return ""
+}
+
 type ExtraField struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -183,11 +262,22 @@ type GormFieldOptions struct {
 	//	*GormFieldOptions_BelongsTo
 	//	*GormFieldOptions_HasMany
 	//	*GormFieldOptions_ManyToMany
-	Association          isGormFieldOptions_Association `protobuf_oneof:"association"`
-	ReferenceOf          string                         `protobuf:"bytes,7,opt,name=reference_of,json=referenceOf,proto3" json:"reference_of,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+	Association isGormFieldOptions_Association `protobuf_oneof:"association"`
+	ReferenceOf string                         `protobuf:"bytes,7,opt,name=reference_of,json=referenceOf,proto3" json:"reference_of,omitempty"`
+	// previous column name, used by the migration diff to rename the column
+	RenamedFrom string `protobuf:"bytes,8,opt,name=renamed_from,json=renamedFrom,proto3" json:"renamed_from,omitempty"`
+	// once a field of the message is filterable (sortable), List requests can
+	// only filter (sort) by the fields that are
+	Filterable bool `protobuf:"varint,9,opt,name=filterable,proto3" json:"filterable,omitempty"`
+	Sortable   bool `protobuf:"varint,10,opt,name=sortable,proto3" json:"sortable,omitempty"`
+	// name of the field in the filters and sort criteria of List requests,
+	// the proto and JSON names if empty
+	ApiName string `protobuf:"bytes,11,opt,name=api_name,json=apiName,proto3" json:"api_name,omitempty"`
+	// stores a message field that is not ormable in a single column
+	Serialize            SerializeFormat `protobuf:"varint,12,opt,name=serialize,proto3,enum=gorm.SerializeFormat" json:"serialize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *GormFieldOptions) Reset()         { *m = GormFieldOptions{} }
@@ -292,6 +382,41 @@ func (m *GormFieldOptions) GetReferenceOf() string {
 	return ""
 }
 
+func (m *GormFieldOptions) GetRenamedFrom() string {
+	if m != nil {
+		return m.RenamedFrom
+	}
+	return ""
+}
+
+func (m *GormFieldOptions) GetFilterable() bool {
+	if m != nil {
+		return m.Filterable
+	}
+	return false
+}
+
+func (m *GormFieldOptions) GetSortable() bool {
+	if m != nil {
+		return m.Sortable
+	}
+	return false
+}
+
+func (m *GormFieldOptions) GetApiName() string {
+	if m != nil {
+		return m.ApiName
+	}
+	return ""
+}
+
+func (m *GormFieldOptions) GetSerialize() SerializeFormat {
+	if m != nil {
+		return m.Serialize
+	}
+	return SerializeFormat_NONE
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*GormFieldOptions) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -326,6 +451,7 @@ type GormTag struct {
 	AssociationAutocreate          bool     `protobuf:"varint,21,opt,name=association_autocreate,json=associationAutocreate,proto3" json:"association_autocreate,omitempty"`
 	AssociationSaveReference       bool     `protobuf:"varint,22,opt,name=association_save_reference,json=associationSaveReference,proto3" json:"association_save_reference,omitempty"`
 	Preload                        bool     `protobuf:"varint,23,opt,name=preload,proto3" json:"preload,omitempty"`
+	Constraint                     string   `protobuf:"bytes,24,opt,name=constraint,proto3" json:"constraint,omitempty"`
 	XXX_NoUnkeyedLiteral           struct{} `json:"-"`
 	XXX_unrecognized               []byte   `json:"-"`
 	XXX_sizecache                  int32    `json:"-"`
@@ -516,6 +642,13 @@ func (m *GormTag) GetPreload() bool {
 	return false
 }
 
+func (m *GormTag) GetConstraint() string {
+	if m != nil {
+		return m.Constraint
+	}
+	return ""
+}
+
 type HasOneOptions struct {
 	Foreignkey               string   `protobuf:"bytes,1,opt,name=foreignkey,proto3" json:"foreignkey,omitempty"`
 	ForeignkeyTag            *GormTag `protobuf:"bytes,2,opt,name=foreignkey_tag,json=foreignkeyTag,proto3" json:"foreignkey_tag,omitempty"`
@@ -965,9 +1098,14 @@ func (m *ManyToManyOptions) GetClear() bool {
 }
 
 type AutoServerOptions struct {
-	Autogen              bool     `protobuf:"varint,1,opt,name=autogen,proto3" json:"autogen,omitempty"`
-	TxnMiddleware        bool     `protobuf:"varint,2,opt,name=txn_middleware,json=txnMiddleware,proto3" json:"txn_middleware,omitempty"`
-	WithTracing          bool     `protobuf:"varint,3,opt,name=with_tracing,json=withTracing,proto3" json:"with_tracing,omitempty"`
+	Autogen       bool `protobuf:"varint,1,opt,name=autogen,proto3" json:"autogen,omitempty"`
+	TxnMiddleware bool `protobuf:"varint,2,opt,name=txn_middleware,json=txnMiddleware,proto3" json:"txn_middleware,omitempty"`
+	WithTracing   bool `protobuf:"varint,3,opt,name=with_tracing,json=withTracing,proto3" json:"with_tracing,omitempty"`
+	// turns the errors of the generated methods into gRPC statuses
+	StatusErrors bool `protobuf:"varint,4,opt,name=status_errors,json=statusErrors,proto3" json:"status_errors,omitempty"`
+	// without txn_middleware, the StrictUpdate, PatchSet and DeleteSet handlers
+	// of the types of the service open a transaction when not given one
+	HandlerTransactions  bool     `protobuf:"varint,5,opt,name=handler_transactions,json=handlerTransactions,proto3" json:"handler_transactions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1018,8 +1156,26 @@ func (m *AutoServerOptions) GetWithTracing() bool {
 	return false
 }
 
+func (m *AutoServerOptions) GetStatusErrors() bool {
+	if m != nil {
+		return m.StatusErrors
+	}
+	return false
+}
+
+func (m *AutoServerOptions) GetHandlerTransactions() bool {
+	if m != nil {
+		return m.HandlerTransactions
+	}
+	return false
+}
+
 type MethodOptions struct {
-	ObjectType           string   `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	ObjectType string `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	// List only, don't count the matching rows into the size of the PageInfo
+	SkipCount bool `protobuf:"varint,2,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	// CreateSet only, rows inserted by each statement, 100 when not set
+	BatchSize            int32    `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1056,6 +1212,20 @@ func (m *MethodOptions) GetObjectType() string {
 	return ""
 }
 
+func (m *MethodOptions) GetSkipCount() bool {
+	if m != nil {
+		return m.SkipCount
+	}
+	return false
+}
+
+func (m *MethodOptions) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
 var E_FileOpts = &proto.ExtensionDesc{
 	ExtendedType:  (*descriptor.FileOptions)(nil),
 	ExtensionType: (*GormFileOptions)(nil),
@@ -1102,6 +1272,7 @@ var E_Method = &proto.ExtensionDesc{
 }
 
 func init() {
+	proto.RegisterEnum("gorm.SerializeFormat", SerializeFormat_name, SerializeFormat_value)
 	proto.RegisterType((*GormFileOptions)(nil), "gorm.GormFileOptions")
 	proto.RegisterType((*GormMessageOptions)(nil), "gorm.GormMessageOptions")
 	proto.RegisterType((*ExtraField)(nil), "gorm.ExtraField")
@@ -1123,82 +1294,100 @@ func init() {
 func init() { proto.RegisterFile("options/gorm.proto", fileDescriptor_69675b971f61ce8f) }
 
 var fileDescriptor_69675b971f61ce8f = []byte{
-	// 1230 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xdd, 0x6e, 0x1c, 0xb5,
-	0x17, 0xff, 0x27, 0xd9, 0x8f, 0xd9, 0xb3, 0xd9, 0x34, 0x71, 0xd3, 0x74, 0xfe, 0x55, 0x69, 0xc3,
-	0xa2, 0x8a, 0x0a, 0xa9, 0x1b, 0x5a, 0x0a, 0x48, 0x29, 0x37, 0xad, 0x44, 0x69, 0x41, 0x4b, 0xd0,
-	0x34, 0x57, 0xdc, 0x8c, 0xbc, 0x33, 0x67, 0x27, 0x6e, 0x67, 0xec, 0xc1, 0xe3, 0x69, 0x77, 0x79,
-	0x09, 0x2e, 0x10, 0xea, 0x3b, 0xf0, 0x30, 0x5c, 0xf0, 0x0e, 0xbc, 0x07, 0xb2, 0x3d, 0x5f, 0x9b,
-	0xdd, 0xad, 0xa2, 0xc0, 0x05, 0x12, 0xdc, 0xf9, 0xfc, 0xce, 0x87, 0x8f, 0xcf, 0x87, 0xed, 0x03,
-	0x44, 0xa4, 0x8a, 0x09, 0x9e, 0x1d, 0x45, 0x42, 0x26, 0xa3, 0x54, 0x0a, 0x25, 0x48, 0x4b, 0xaf,
-	0x6f, 0x1c, 0x46, 0x42, 0x44, 0x31, 0x1e, 0x19, 0x6c, 0x92, 0x4f, 0x8f, 0x42, 0xcc, 0x02, 0xc9,
-	0x52, 0x25, 0xa4, 0x95, 0x1b, 0xee, 0xc1, 0x95, 0xaf, 0x84, 0x4c, 0x9e, 0xb2, 0x18, 0x4f, 0xac,
-	0x95, 0xe1, 0x2f, 0x1b, 0x40, 0x34, 0x36, 0xc6, 0x2c, 0xa3, 0x51, 0x09, 0x13, 0x17, 0xba, 0x42,
-	0x26, 0x74, 0x12, 0xa3, 0xbb, 0x71, 0xb8, 0x71, 0xd7, 0xf1, 0x4a, 0x92, 0x7c, 0x04, 0x5d, 0xc6,
-	0x83, 0x38, 0x0f, 0xd1, 0xdd, 0x3c, 0xdc, 0xba, 0xdb, 0x7f, 0xb0, 0x3b, 0x32, 0x9e, 0x7c, 0x39,
-	0x53, 0x92, 0x3e, 0x65, 0x18, 0x87, 0x5e, 0x29, 0x40, 0xf6, 0xa1, 0xad, 0x8c, 0x8d, 0xad, 0xc3,
-	0x8d, 0xbb, 0x3d, 0xcf, 0x12, 0xe4, 0x03, 0x18, 0x24, 0x79, 0xac, 0x98, 0x4f, 0x83, 0x40, 0xe4,
-	0x5c, 0xb9, 0x2d, 0xb3, 0xc3, 0xb6, 0x01, 0x1f, 0x5b, 0x6c, 0x28, 0x00, 0x6a, 0x8b, 0x84, 0x40,
-	0x4b, 0xcd, 0x53, 0xeb, 0x4b, 0xcf, 0x33, 0x6b, 0x8d, 0x71, 0x9a, 0x68, 0x2f, 0x0c, 0xa6, 0xd7,
-	0xe4, 0x36, 0x6c, 0x29, 0x1a, 0x99, 0xed, 0xfa, 0x0f, 0x06, 0xd6, 0x31, 0x7d, 0xba, 0x53, 0x1a,
-	0x79, 0x9a, 0xa3, 0xcf, 0x95, 0xd2, 0xe0, 0x15, 0x8d, 0xd0, 0xec, 0xda, 0xf3, 0x4a, 0x72, 0xf8,
-	0xfb, 0x26, 0xec, 0xda, 0xe0, 0x60, 0x1c, 0x96, 0x61, 0x28, 0xec, 0x6d, 0xac, 0xb5, 0x47, 0xa0,
-	0x15, 0x4a, 0x91, 0x1a, 0x27, 0x1c, 0xcf, 0xac, 0xc9, 0x08, 0xba, 0x67, 0x34, 0xf3, 0x05, 0xc7,
-	0xc2, 0x91, 0xab, 0x56, 0xf1, 0x19, 0xcd, 0x4e, 0x78, 0x19, 0xe1, 0x67, 0xff, 0xf3, 0x3a, 0x67,
-	0x06, 0x20, 0x9f, 0x03, 0x4c, 0x30, 0x16, 0x3c, 0xca, 0x7c, 0x25, 0x8c, 0x5b, 0xfd, 0x07, 0x07,
-	0x56, 0xe5, 0x89, 0xc5, 0x4f, 0x45, 0xad, 0xd5, 0x9b, 0x94, 0x18, 0xb9, 0x0f, 0x8e, 0xde, 0x28,
-	0xa1, 0x7c, 0xee, 0xb6, 0x8d, 0xda, 0x7e, 0xb5, 0xd3, 0x98, 0xf2, 0x79, 0xad, 0xa4, 0x1d, 0xd2,
-	0x08, 0x79, 0x04, 0xdb, 0x5a, 0xdc, 0x57, 0xc2, 0xaa, 0x75, 0x8c, 0xda, 0x75, 0xab, 0xa6, 0x25,
-	0x4e, 0xc5, 0xa2, 0x26, 0x24, 0x15, 0x48, 0xde, 0x87, 0x6d, 0x89, 0x53, 0x94, 0xc8, 0x03, 0xf4,
-	0xc5, 0xd4, 0xed, 0x9a, 0x08, 0xf6, 0x2b, 0xec, 0x64, 0xfa, 0x64, 0x00, 0x7d, 0x9a, 0x65, 0x22,
-	0x60, 0x54, 0x1b, 0x18, 0xfe, 0xd6, 0x81, 0x6e, 0x11, 0x2f, 0x72, 0x00, 0x9d, 0x40, 0xc4, 0x79,
-	0xc2, 0x8b, 0x2c, 0x16, 0x54, 0x95, 0xdb, 0xcd, 0xc5, 0xdc, 0x66, 0xec, 0x47, 0x1b, 0xbf, 0xb6,
-	0x67, 0xd6, 0xe4, 0x26, 0xf4, 0x52, 0x89, 0x01, 0xcb, 0x98, 0xe0, 0x26, 0x4a, 0x6d, 0xaf, 0x06,
-	0xc8, 0x6d, 0xe8, 0xa7, 0x92, 0x25, 0x54, 0xce, 0xfd, 0x57, 0x68, 0xc3, 0xe1, 0x78, 0x50, 0x40,
-	0xdf, 0xe0, 0x5c, 0x6f, 0x9f, 0x73, 0xf6, 0x43, 0x8e, 0xe6, 0xcc, 0x8e, 0x57, 0x50, 0xba, 0x22,
-	0x42, 0x9c, 0xd2, 0x3c, 0x56, 0xc5, 0x79, 0x4a, 0x92, 0xfc, 0x1f, 0x1c, 0x2e, 0x94, 0xcf, 0xf3,
-	0x38, 0x76, 0x1d, 0xdb, 0x04, 0x5c, 0xa8, 0x6f, 0xf3, 0x38, 0x26, 0x77, 0x60, 0x87, 0xe6, 0x4a,
-	0xf8, 0x8c, 0x07, 0x12, 0x13, 0xe4, 0xca, 0xed, 0x19, 0x81, 0x81, 0x46, 0x9f, 0x97, 0xa0, 0xae,
-	0x7f, 0xc6, 0x43, 0x9c, 0xb9, 0x60, 0xeb, 0xdf, 0x10, 0x3a, 0x8c, 0x76, 0x6f, 0xdf, 0x32, 0xfb,
-	0x36, 0x8c, 0x16, 0x7b, 0x6e, 0x44, 0x6e, 0x80, 0x83, 0xc9, 0x04, 0xc3, 0x10, 0x43, 0x77, 0xdb,
-	0x58, 0xae, 0x68, 0xf2, 0x21, 0x5c, 0x29, 0xd7, 0x7e, 0x2a, 0x71, 0xca, 0x66, 0xee, 0xc0, 0x58,
-	0xd8, 0x29, 0xe1, 0xef, 0x0c, 0xaa, 0x4f, 0xcc, 0x22, 0x2e, 0x24, 0xba, 0x3b, 0xf6, 0xc4, 0x96,
-	0x22, 0xb7, 0x00, 0xa6, 0x42, 0x22, 0x8b, 0xb8, 0x8e, 0xd4, 0x15, 0xa3, 0xdb, 0x40, 0xc8, 0xa7,
-	0x70, 0xd0, 0xc8, 0xa1, 0xdf, 0x90, 0xdd, 0x35, 0xb2, 0xd7, 0x1a, 0xdc, 0xa7, 0xb5, 0xda, 0xe1,
-	0xb9, 0xd2, 0xda, 0xb3, 0x86, 0x1b, 0xf5, 0x73, 0x1f, 0xf6, 0x5f, 0x0a, 0xc6, 0xcd, 0x2d, 0xd0,
-	0x34, 0x4b, 0x8c, 0xe4, 0xd5, 0x8a, 0xd7, 0x30, 0xfa, 0x0c, 0x0e, 0x9b, 0xbe, 0xac, 0x54, 0xbf,
-	0x6a, 0xd4, 0x6f, 0x35, 0xe4, 0xbe, 0x5e, 0x61, 0xe9, 0xdc, 0xa9, 0x74, 0xa2, 0xf2, 0x34, 0xa4,
-	0x0a, 0xdd, 0x7d, 0x13, 0x9d, 0xe6, 0xa9, 0x1e, 0x57, 0xcc, 0x55, 0x6a, 0x81, 0x44, 0xad, 0x76,
-	0x6d, 0xa5, 0x9a, 0x65, 0x92, 0x2f, 0xe0, 0x46, 0x53, 0x2d, 0xa3, 0xaf, 0xd1, 0xaf, 0xfa, 0xc4,
-	0x3d, 0x30, 0xaa, 0x6e, 0x43, 0xe2, 0x05, 0x7d, 0x8d, 0x5e, 0xc9, 0x37, 0xb7, 0x94, 0xc4, 0x58,
-	0xd0, 0xd0, 0xbd, 0x6e, 0x0b, 0xaf, 0x20, 0x87, 0xbf, 0x6e, 0xc1, 0x60, 0xe1, 0x1e, 0x39, 0x97,
-	0xcd, 0x8d, 0xa5, 0x6c, 0x3e, 0x84, 0x9d, 0x9a, 0xf2, 0xf5, 0x6d, 0xb6, 0xb9, 0xea, 0x36, 0x1b,
-	0xd4, 0x42, 0xba, 0x59, 0xd7, 0xd7, 0xc0, 0xd6, 0xbb, 0x6a, 0x60, 0x7d, 0x90, 0x5b, 0x97, 0x0b,
-	0x72, 0xfb, 0xf2, 0x41, 0xee, 0x5c, 0x3c, 0xc8, 0xdd, 0x85, 0x20, 0x6b, 0x8e, 0xc4, 0x34, 0xa6,
-	0x01, 0x96, 0x7d, 0x5f, 0x90, 0xba, 0xa5, 0x68, 0x9a, 0x22, 0x0f, 0x8b, 0x7e, 0x2f, 0x28, 0xdd,
-	0xe8, 0x41, 0x8c, 0x54, 0x9a, 0x46, 0x77, 0x3c, 0x4b, 0x0c, 0xff, 0xd8, 0x84, 0xdd, 0xf3, 0x37,
-	0xf8, 0x7f, 0xf9, 0xfa, 0xdb, 0xf3, 0x35, 0xfc, 0xa9, 0x05, 0x3b, 0x8b, 0x4f, 0xde, 0x3f, 0x2b,
-	0xca, 0x77, 0x60, 0x27, 0x15, 0x19, 0xb3, 0x3a, 0xfa, 0x7b, 0x51, 0xfc, 0x3d, 0x06, 0x25, 0x6a,
-	0x3f, 0x39, 0x8f, 0x80, 0x2c, 0x8a, 0x19, 0xbf, 0xda, 0xab, 0xfc, 0xda, 0x5d, 0xd0, 0x5c, 0xe1,
-	0x5a, 0x23, 0x93, 0x9d, 0xcb, 0x65, 0xb2, 0x7b, 0xf9, 0x4c, 0x3a, 0x17, 0xcf, 0x64, 0x6f, 0x6d,
-	0xe7, 0xc1, 0xba, 0xce, 0xeb, 0xaf, 0xee, 0xbc, 0xed, 0x66, 0xe7, 0xfd, 0xdc, 0x82, 0xbd, 0xa5,
-	0xdf, 0x8c, 0xfe, 0x41, 0x54, 0x0f, 0x48, 0x51, 0x13, 0x35, 0x70, 0xae, 0x64, 0x36, 0x97, 0x4a,
-	0x66, 0xdd, 0xeb, 0xb5, 0xb5, 0xfe, 0xf5, 0x5a, 0x5f, 0x2f, 0xad, 0x77, 0xd5, 0xcb, 0x45, 0x1e,
-	0xbd, 0xf6, 0x5f, 0x7c, 0xf4, 0xfe, 0x25, 0x55, 0x31, 0x68, 0x56, 0xc5, 0x1b, 0xd8, 0xd3, 0xde,
-	0xbe, 0x40, 0xf9, 0x1a, 0x65, 0x63, 0xd2, 0xd1, 0xe7, 0x8b, 0x90, 0x97, 0x93, 0x4e, 0x41, 0xea,
-	0xb6, 0x55, 0x33, 0xee, 0x27, 0x2c, 0x0c, 0x63, 0x7c, 0x43, 0x25, 0x16, 0xbf, 0xfc, 0x81, 0x9a,
-	0xf1, 0x71, 0x05, 0xea, 0xef, 0xdc, 0x1b, 0xa6, 0xce, 0x7c, 0x25, 0x69, 0xc0, 0xb8, 0x1d, 0x3e,
-	0x1c, 0xaf, 0xaf, 0xb1, 0x53, 0x0b, 0x0d, 0x3f, 0x86, 0xc1, 0x18, 0xd5, 0x99, 0x68, 0xcc, 0x15,
-	0x7d, 0x31, 0x79, 0x89, 0x81, 0xf2, 0x1b, 0x63, 0x0d, 0x58, 0xe8, 0x74, 0x9e, 0xe2, 0xb1, 0x07,
-	0xbd, 0x29, 0x8b, 0xd1, 0x17, 0xa9, 0xca, 0xc8, 0xcd, 0x91, 0x9d, 0xec, 0x46, 0xe5, 0x64, 0x37,
-	0x6a, 0x4c, 0x70, 0xee, 0xdb, 0xb7, 0x76, 0xca, 0xb8, 0x56, 0x5f, 0x11, 0x0d, 0xb6, 0xe7, 0x4c,
-	0x2d, 0x91, 0x1d, 0x9f, 0x40, 0xcb, 0x98, 0xbb, 0xbd, 0x64, 0x6e, 0x71, 0xf8, 0xab, 0x2c, 0xba,
-	0xb5, 0xc5, 0x45, 0x09, 0xcf, 0x18, 0x3a, 0x1e, 0x43, 0xdb, 0xdc, 0x53, 0xe4, 0xbd, 0x15, 0x0e,
-	0xd6, 0x53, 0x54, 0x65, 0xef, 0xa0, 0xe9, 0x61, 0xcd, 0xf7, 0xac, 0x95, 0x63, 0x0f, 0x3a, 0x99,
-	0x49, 0xcd, 0x0a, 0x0f, 0x75, 0xce, 0x58, 0xb0, 0xe4, 0x61, 0x31, 0xb8, 0x2c, 0x65, 0xd5, 0x2b,
-	0x2c, 0x1d, 0x8f, 0xa1, 0x93, 0x98, 0xc8, 0x93, 0x5b, 0x2b, 0x4e, 0xdd, 0x48, 0x49, 0x65, 0xb2,
-	0x18, 0xd6, 0x16, 0x98, 0x5e, 0x61, 0xe4, 0xc9, 0x67, 0xdf, 0x3f, 0x8c, 0x98, 0x3a, 0xcb, 0x27,
-	0xa3, 0x40, 0x24, 0x47, 0x8c, 0x4f, 0xc5, 0x24, 0x16, 0x33, 0x91, 0x22, 0xb7, 0x53, 0x77, 0x70,
-	0x2f, 0x42, 0x7e, 0x4f, 0x1b, 0x38, 0x2a, 0xc6, 0xf4, 0x47, 0x9a, 0x98, 0x74, 0x0c, 0xf7, 0x93,
-	0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc0, 0x01, 0x6f, 0xe9, 0xbd, 0x0f, 0x00, 0x00,
+	// 1507 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xdf, 0x6e, 0xdb, 0xb6,
+	0x17, 0xae, 0x1d, 0xff, 0x91, 0x8e, 0xe3, 0xc4, 0x61, 0xfe, 0x54, 0xbf, 0xfc, 0xda, 0x34, 0xf3,
+	0x50, 0x2c, 0x28, 0xd0, 0x04, 0x4d, 0x37, 0x14, 0x48, 0x77, 0xd3, 0x6c, 0xcd, 0xda, 0x0e, 0x89,
+	0x0b, 0x25, 0x37, 0xdb, 0x8d, 0x40, 0x4b, 0xb4, 0xcd, 0x46, 0x22, 0x35, 0x8a, 0x4a, 0x93, 0xde,
+	0xed, 0x09, 0x76, 0xb1, 0x8b, 0xbe, 0xc3, 0x5e, 0x61, 0x0f, 0xb1, 0xa7, 0xd8, 0xed, 0x9e, 0x61,
+	0x20, 0x29, 0x59, 0x72, 0x6c, 0x17, 0x45, 0xb6, 0x8b, 0x01, 0xdb, 0x9d, 0xce, 0x77, 0x78, 0x8e,
+	0x0e, 0xcf, 0xf7, 0x91, 0x22, 0x05, 0x88, 0xc7, 0x92, 0x72, 0x96, 0xec, 0x0d, 0xb9, 0x88, 0x76,
+	0x63, 0xc1, 0x25, 0x47, 0x35, 0xf5, 0xbc, 0xb9, 0x3d, 0xe4, 0x7c, 0x18, 0x92, 0x3d, 0x8d, 0xf5,
+	0xd3, 0xc1, 0x5e, 0x40, 0x12, 0x5f, 0xd0, 0x58, 0x72, 0x61, 0xc6, 0x75, 0x57, 0x60, 0xf9, 0x1b,
+	0x2e, 0xa2, 0x23, 0x1a, 0x92, 0x9e, 0xc9, 0xd2, 0xfd, 0xb5, 0x0a, 0x48, 0x61, 0xc7, 0x24, 0x49,
+	0xf0, 0x30, 0x87, 0x91, 0x03, 0x4d, 0x2e, 0x22, 0xdc, 0x0f, 0x89, 0x53, 0xd9, 0xae, 0xec, 0x58,
+	0x6e, 0x6e, 0xa2, 0x07, 0xd0, 0xa4, 0xcc, 0x0f, 0xd3, 0x80, 0x38, 0xd5, 0xed, 0x85, 0x9d, 0xd6,
+	0x7e, 0x67, 0x57, 0x57, 0xf2, 0xfc, 0x52, 0x0a, 0x7c, 0x44, 0x49, 0x18, 0xb8, 0xf9, 0x00, 0xb4,
+	0x06, 0x75, 0xa9, 0x73, 0x2c, 0x6c, 0x57, 0x76, 0x6c, 0xd7, 0x18, 0xe8, 0x53, 0x68, 0x47, 0x69,
+	0x28, 0xa9, 0x87, 0x7d, 0x9f, 0xa7, 0x4c, 0x3a, 0x35, 0xfd, 0x86, 0x45, 0x0d, 0x3e, 0x33, 0x18,
+	0xfa, 0x04, 0x16, 0x05, 0x61, 0x38, 0x22, 0x81, 0x37, 0x10, 0x3c, 0x72, 0xea, 0x3a, 0x43, 0x2b,
+	0xc3, 0x8e, 0x04, 0x8f, 0xd0, 0x3d, 0x68, 0x25, 0x7c, 0x20, 0xbd, 0x80, 0x84, 0x44, 0x12, 0xa7,
+	0xa1, 0xb3, 0x80, 0x82, 0xbe, 0xd6, 0x08, 0xda, 0x02, 0x90, 0x34, 0x22, 0x89, 0xc4, 0x51, 0x9c,
+	0x38, 0x4d, 0xe3, 0x2f, 0x10, 0x55, 0xc8, 0x05, 0x11, 0x09, 0xe5, 0xcc, 0x1b, 0xa8, 0xc2, 0x1d,
+	0x4b, 0xbf, 0x64, 0x31, 0x03, 0xf5, 0x64, 0xd0, 0xff, 0xc1, 0x4e, 0xe3, 0x84, 0x08, 0xe9, 0x71,
+	0xe6, 0xd8, 0x7a, 0x80, 0x65, 0x80, 0x1e, 0xeb, 0x72, 0x80, 0x62, 0xde, 0x08, 0x41, 0x4d, 0x5e,
+	0xc5, 0xa6, 0x63, 0xb6, 0xab, 0x9f, 0x15, 0xa6, 0x2a, 0x76, 0xaa, 0x06, 0x53, 0xcf, 0xe8, 0x1e,
+	0x2c, 0x48, 0x3c, 0xd4, 0x4d, 0x69, 0xed, 0xb7, 0x4d, 0xfb, 0x14, 0x07, 0x67, 0x78, 0xe8, 0x2a,
+	0x8f, 0xea, 0x7e, 0x8c, 0xfd, 0x73, 0x3c, 0x24, 0xba, 0x37, 0xb6, 0x9b, 0x9b, 0xdd, 0x1f, 0x6b,
+	0xd0, 0x31, 0x14, 0x92, 0x30, 0xc8, 0xc9, 0xca, 0xf2, 0x55, 0xe6, 0xe6, 0x43, 0x50, 0x0b, 0x04,
+	0x8f, 0x75, 0x11, 0x96, 0xab, 0x9f, 0xd1, 0x2e, 0x34, 0x47, 0x38, 0xf1, 0x38, 0x23, 0x59, 0x21,
+	0xab, 0x26, 0xf0, 0x05, 0x4e, 0x7a, 0x2c, 0xd7, 0xc1, 0x8b, 0x5b, 0x6e, 0x63, 0xa4, 0x01, 0xf4,
+	0x04, 0xa0, 0x4f, 0x42, 0xce, 0x86, 0x89, 0x27, 0xb9, 0x2e, 0xab, 0xb5, 0xbf, 0x61, 0x42, 0x0e,
+	0x0d, 0x7e, 0xc6, 0x8b, 0x28, 0xbb, 0x9f, 0x63, 0xe8, 0x11, 0x58, 0xea, 0x45, 0x11, 0x66, 0x57,
+	0x9a, 0xc5, 0xd6, 0xfe, 0xda, 0xf8, 0x4d, 0xc7, 0x98, 0x5d, 0x15, 0x41, 0xcd, 0x91, 0x41, 0xd0,
+	0x53, 0x58, 0x54, 0xc3, 0x3d, 0xc9, 0x4d, 0x58, 0x43, 0x87, 0xdd, 0x36, 0x61, 0x6a, 0xc4, 0x19,
+	0x9f, 0x8c, 0x84, 0x68, 0x0c, 0x1a, 0xe5, 0x0c, 0x88, 0x20, 0xcc, 0x27, 0x1e, 0x1f, 0x38, 0xcd,
+	0x5c, 0x39, 0x19, 0xd6, 0x1b, 0x4c, 0x89, 0xcb, 0x9a, 0x16, 0xd7, 0x16, 0xc0, 0x80, 0x86, 0x92,
+	0x08, 0xad, 0x5f, 0xdb, 0x68, 0xa7, 0x40, 0xd0, 0x26, 0x58, 0x09, 0x17, 0x46, 0xdd, 0xa0, 0xbd,
+	0x63, 0x1b, 0xfd, 0x0f, 0x2c, 0x1c, 0x53, 0x4f, 0xf3, 0xde, 0x32, 0xfc, 0xe1, 0x98, 0x9e, 0x28,
+	0xea, 0x1f, 0x83, 0x9d, 0x10, 0x41, 0x71, 0x48, 0xdf, 0x11, 0x67, 0x71, 0xbb, 0xb2, 0xb3, 0xb4,
+	0xbf, 0x6e, 0xa6, 0x75, 0x9a, 0xc3, 0x47, 0x6a, 0xa5, 0x49, 0xb7, 0x18, 0x77, 0xd8, 0x86, 0x16,
+	0x4e, 0x12, 0xee, 0x53, 0xac, 0xe6, 0xdb, 0xfd, 0xa3, 0x01, 0xcd, 0x8c, 0x5e, 0xb4, 0x01, 0x0d,
+	0x9f, 0x87, 0x69, 0xc4, 0x32, 0xd1, 0x65, 0xd6, 0x58, 0x8a, 0xd5, 0x49, 0x29, 0x26, 0xf4, 0x9d,
+	0xa1, 0xbb, 0xee, 0xea, 0x67, 0x74, 0x07, 0xec, 0x58, 0x10, 0x9f, 0x2a, 0xbd, 0x6b, 0x52, 0xeb,
+	0x6e, 0x01, 0xa8, 0x15, 0x16, 0x0b, 0x1a, 0x61, 0x71, 0xe5, 0x9d, 0x13, 0xc3, 0x9e, 0xe5, 0x42,
+	0x06, 0x7d, 0x4b, 0xae, 0xd4, 0xeb, 0x53, 0x46, 0x7f, 0x48, 0xf3, 0xd5, 0x97, 0x59, 0x4a, 0xc0,
+	0x01, 0x19, 0xe0, 0x34, 0x94, 0x59, 0xfb, 0x73, 0x53, 0xf5, 0x86, 0x71, 0xe9, 0xb1, 0x34, 0x0c,
+	0x75, 0xdb, 0x2d, 0xb7, 0xc9, 0xb8, 0x3c, 0x49, 0xc3, 0x10, 0xdd, 0x87, 0x25, 0x9c, 0x4a, 0xee,
+	0x51, 0xe6, 0x0b, 0x12, 0x11, 0x26, 0xb3, 0xb6, 0xb7, 0x15, 0xfa, 0x32, 0x07, 0xd5, 0xa6, 0x42,
+	0x59, 0x40, 0x2e, 0x75, 0xdb, 0x6d, 0xd7, 0x18, 0x8a, 0x52, 0xf3, 0x6e, 0xcf, 0x38, 0x4d, 0xdf,
+	0x5b, 0x06, 0x7b, 0xa9, 0x87, 0x6c, 0x82, 0x45, 0xa2, 0x3e, 0x09, 0x02, 0x12, 0xe8, 0xd6, 0x5b,
+	0xee, 0xd8, 0x46, 0x9f, 0xc1, 0x72, 0xfe, 0xec, 0xc5, 0x82, 0x0c, 0xe8, 0xa5, 0xd3, 0xd6, 0x19,
+	0x96, 0x72, 0xf8, 0xb5, 0x46, 0xd5, 0x8c, 0xe9, 0x90, 0x71, 0x41, 0x9c, 0x25, 0x33, 0x63, 0x63,
+	0x69, 0xbd, 0x70, 0x41, 0xe8, 0x90, 0xa9, 0x4e, 0x2d, 0xeb, 0xd8, 0x12, 0x82, 0xbe, 0x80, 0x8d,
+	0x12, 0x87, 0x5e, 0x69, 0x6c, 0x47, 0x8f, 0x5d, 0x2f, 0x79, 0x8f, 0x8a, 0xb0, 0xed, 0x6b, 0x2b,
+	0x61, 0xc5, 0x24, 0x2e, 0xc9, 0xfd, 0x11, 0xac, 0xbd, 0xe1, 0x94, 0x69, 0xe5, 0x95, 0xd3, 0x22,
+	0x3d, 0x72, 0x75, 0xec, 0x2b, 0x25, 0x7d, 0x01, 0xdb, 0xe5, 0x5a, 0x66, 0x86, 0xaf, 0xea, 0xf0,
+	0xad, 0xd2, 0xb8, 0x57, 0x33, 0x32, 0x5d, 0x9b, 0x95, 0x22, 0x2a, 0x8d, 0x03, 0x2c, 0x89, 0xb3,
+	0xa6, 0xbb, 0x53, 0x9e, 0xd5, 0xb3, 0xb1, 0x73, 0x56, 0x98, 0x2f, 0x88, 0x0a, 0x5b, 0x9f, 0x19,
+	0x66, 0x9c, 0xe8, 0x4b, 0xd8, 0x2c, 0x87, 0x25, 0xf8, 0x82, 0x78, 0xe3, 0x65, 0xed, 0x6c, 0xe8,
+	0x50, 0xa7, 0x34, 0xe2, 0x14, 0x5f, 0x10, 0x37, 0xf7, 0xeb, 0x4d, 0x55, 0x90, 0x90, 0xe3, 0xc0,
+	0xb9, 0x6d, 0x84, 0x97, 0x99, 0x8a, 0x3b, 0x9f, 0xb3, 0x44, 0x0a, 0x4c, 0x99, 0x74, 0x1c, 0xd3,
+	0xe2, 0x02, 0xe9, 0xfe, 0xb2, 0x00, 0xed, 0x89, 0x6d, 0xf1, 0x1a, 0xdb, 0x95, 0x29, 0xb6, 0x3f,
+	0x87, 0xa5, 0xc2, 0xf2, 0xd4, 0xe6, 0x5c, 0x9d, 0xb5, 0x39, 0xb7, 0x8b, 0x41, 0x6a, 0x31, 0xcf,
+	0xd7, 0xc8, 0xc2, 0x87, 0x34, 0x32, 0x9f, 0x84, 0xda, 0xcd, 0x48, 0xa8, 0xdf, 0x9c, 0x84, 0xc6,
+	0xc7, 0x93, 0xd0, 0x9c, 0x24, 0xc1, 0x81, 0xa6, 0x20, 0x71, 0x88, 0x7d, 0x92, 0xef, 0x0b, 0x99,
+	0xa9, 0x96, 0x1c, 0x8e, 0x63, 0xc2, 0x82, 0x6c, 0x3f, 0xc8, 0x2c, 0xb5, 0x11, 0xf8, 0x21, 0xc1,
+	0x22, 0xdb, 0x7f, 0x8d, 0xd1, 0xfd, 0xbd, 0x0a, 0x9d, 0xeb, 0x1f, 0xa4, 0xff, 0xf8, 0xfa, 0xdb,
+	0xf9, 0xea, 0xfe, 0x54, 0x83, 0xa5, 0xc9, 0x2f, 0xf8, 0x3f, 0xab, 0xcb, 0xf7, 0x61, 0x29, 0xe6,
+	0x09, 0x95, 0xc5, 0xe9, 0xce, 0x1c, 0xa5, 0xda, 0x39, 0x6a, 0xce, 0x6c, 0x4f, 0x01, 0x4d, 0x0e,
+	0xd3, 0x75, 0xd5, 0x67, 0xd5, 0xd5, 0x99, 0x88, 0x9c, 0x51, 0x5a, 0x89, 0xc9, 0xc6, 0xcd, 0x98,
+	0x6c, 0xde, 0x9c, 0x49, 0xeb, 0xe3, 0x99, 0xb4, 0xe7, 0xae, 0x3c, 0x98, 0xb7, 0xf2, 0x5a, 0xb3,
+	0x57, 0xde, 0x62, 0x79, 0xe5, 0xfd, 0x5c, 0x83, 0x95, 0xa9, 0xc3, 0x99, 0x3a, 0x61, 0x8c, 0x3f,
+	0x30, 0x99, 0x26, 0x0a, 0xe0, 0x9a, 0x64, 0xaa, 0x53, 0x92, 0x99, 0xf7, 0x75, 0x5b, 0x98, 0xff,
+	0x75, 0x9b, 0xaf, 0x97, 0xda, 0x87, 0xf4, 0xf2, 0x31, 0x1f, 0xc5, 0xfa, 0x5f, 0xfc, 0x28, 0xfe,
+	0x4b, 0x54, 0xd1, 0x2e, 0xab, 0xe2, 0xb7, 0x0a, 0xac, 0xa8, 0x72, 0x4f, 0x89, 0xb8, 0x20, 0xa2,
+	0x74, 0xbf, 0x54, 0x13, 0x1c, 0x12, 0x96, 0xdf, 0x2f, 0x33, 0x53, 0xad, 0x5b, 0x79, 0xc9, 0xbc,
+	0x88, 0x06, 0x41, 0x48, 0xde, 0x62, 0x41, 0xb2, 0x5b, 0x4b, 0x5b, 0x5e, 0xb2, 0xe3, 0x31, 0xa8,
+	0xce, 0x7b, 0x6f, 0xa9, 0x1c, 0x79, 0x52, 0x60, 0x9f, 0x32, 0x73, 0x99, 0xb2, 0xdc, 0x96, 0xc2,
+	0xce, 0x0c, 0xa4, 0xae, 0x77, 0x89, 0xc4, 0x32, 0x4d, 0x3c, 0x22, 0x04, 0x17, 0x49, 0x7e, 0xcf,
+	0x34, 0xe0, 0x73, 0x8d, 0x29, 0x81, 0x8d, 0x30, 0x0b, 0x42, 0x22, 0x54, 0x2a, 0x96, 0x60, 0x5f,
+	0x17, 0x98, 0xed, 0xa9, 0xab, 0x99, 0xef, 0xac, 0xe4, 0xea, 0x32, 0x68, 0x1f, 0x13, 0x39, 0xe2,
+	0xa5, 0xfb, 0x57, 0x8b, 0xf7, 0xdf, 0x10, 0x5f, 0x7a, 0xa5, 0xeb, 0x1f, 0x18, 0xe8, 0x4c, 0x9d,
+	0xbc, 0xef, 0x02, 0x24, 0xe7, 0x34, 0xf6, 0xcc, 0x75, 0xd7, 0xcc, 0xc7, 0x56, 0xc8, 0x57, 0x0a,
+	0x50, 0xee, 0x3e, 0x96, 0xfe, 0xc8, 0x2b, 0x1d, 0xcf, 0x6d, 0x8d, 0x9c, 0xd2, 0x77, 0xe4, 0xc1,
+	0x13, 0x58, 0xbe, 0x76, 0x39, 0x40, 0x16, 0xd4, 0x4e, 0x7a, 0x27, 0xcf, 0x3b, 0xb7, 0xd4, 0xd3,
+	0xab, 0xd3, 0xde, 0x49, 0xa7, 0x82, 0x3a, 0xb0, 0xf8, 0xda, 0xed, 0x9d, 0xf5, 0xbc, 0xc3, 0x97,
+	0x27, 0xcf, 0xdc, 0xef, 0x3a, 0xd5, 0x03, 0x17, 0xec, 0x01, 0x0d, 0x89, 0xc7, 0x63, 0x99, 0xa0,
+	0x3b, 0xbb, 0xe6, 0xf7, 0xc0, 0x6e, 0xfe, 0x7b, 0x60, 0xb7, 0xf4, 0x1b, 0xc0, 0x79, 0xff, 0xde,
+	0x5c, 0x02, 0xd7, 0x8b, 0x2d, 0xaf, 0xe4, 0x76, 0xad, 0x81, 0x31, 0x92, 0x83, 0x1e, 0xd4, 0x74,
+	0xba, 0x7b, 0x53, 0xe9, 0x26, 0xff, 0x20, 0x8c, 0x33, 0x3a, 0x45, 0xc6, 0xc9, 0x11, 0xae, 0x4e,
+	0x74, 0x70, 0x0c, 0x75, 0xbd, 0xef, 0xa2, 0xbb, 0x33, 0x0a, 0x2c, 0x2e, 0xb9, 0xe3, 0x7c, 0x1b,
+	0xe5, 0x0a, 0x0b, 0xbf, 0x6b, 0xb2, 0x1c, 0xb8, 0xd0, 0x48, 0xb4, 0xd2, 0x66, 0x54, 0xa8, 0x24,
+	0x48, 0xfd, 0xa9, 0x0a, 0xb3, 0x7b, 0xe5, 0x94, 0x48, 0xdd, 0x2c, 0xd3, 0xc1, 0x31, 0x34, 0x22,
+	0x4d, 0x38, 0xda, 0x9a, 0x31, 0xeb, 0x92, 0x12, 0xc6, 0x29, 0xb3, 0xbb, 0xf4, 0x84, 0xd3, 0xcd,
+	0x92, 0x1c, 0x3e, 0xfa, 0x7e, 0x6f, 0x48, 0xe5, 0x28, 0xed, 0xef, 0xfa, 0x3c, 0xda, 0x4b, 0xd2,
+	0x54, 0xe2, 0xf3, 0xd4, 0xfc, 0xb5, 0xf1, 0x1f, 0x0e, 0x09, 0x7b, 0xa8, 0x62, 0xf7, 0xb2, 0xdf,
+	0x3c, 0x4f, 0x95, 0xd1, 0x6f, 0x68, 0xef, 0xe3, 0x3f, 0x07, 0x00, 0x22, 0xd0, 0x13, 0xc5, 0xfd,
+	0x11, 0x00, 0x00,
 }
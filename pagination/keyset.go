@@ -0,0 +1,107 @@
+// Package pagination implements the keyset pagination of the generated List
+// handlers: the rows of a page are the ones following the last row of the
+// previous page in the sort order, which the page token identifies
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FirstPageToken is the page token requesting the first page of a keyset
+// paginated List, and the one returned with the last page
+const FirstPageToken = "null"
+
+// Key is a column of the keyset sort order, with the value of the row
+type Key struct {
+	// Field is the name of the ORM struct field
+	Field  string
+	Column string
+	Desc   bool
+	Value  interface{}
+}
+
+// Order returns the ORDER BY clause sorting the rows by the keys
+func Order(keys []Key) string {
+	var order []string
+	for _, key := range keys {
+		if key.Desc {
+			order = append(order, key.Column+" desc")
+		} else {
+			order = append(order, key.Column)
+		}
+	}
+	return strings.Join(order, ",")
+}
+
+// Where returns the condition selecting the rows that follow the row having
+// the values of the keys, (a > ?) OR (a = ? AND b > ?) OR ... The keys must
+// have ordered values: NULL compares to nothing, and maps and slices other
+// than []byte have no order
+func Where(keys []Key) (string, []interface{}, error) {
+	var or []string
+	var args []interface{}
+	for i, key := range keys {
+		if !isOrdered(key.Value) {
+			return "", nil, fmt.Errorf("cannot sort by %s with a page token", key.Column)
+		}
+		var and []string
+		for _, prev := range keys[:i] {
+			and = append(and, prev.Column+" = ?")
+			args = append(args, prev.Value)
+		}
+		op := ">"
+		if key.Desc {
+			op = "<"
+		}
+		and = append(and, fmt.Sprintf("%s %s ?", key.Column, op))
+		args = append(args, key.Value)
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+	}
+	return strings.Join(or, " OR "), args, nil
+}
+
+func isOrdered(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return false
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil() && isOrdered(v.Elem().Interface())
+	case reflect.Map:
+		return false
+	case reflect.Slice:
+		return v.Type().Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+// EncodeToken returns the page token of the rows following the row having
+// the values of the keys
+func EncodeToken(keys []Key) (string, error) {
+	values := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		values[key.Field] = key.Value
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeToken sets the fields of the ORM struct dest to the values of the
+// page token
+func DecodeToken(token string, dest interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("invalid page token: %v", err)
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("invalid page token: %v", err)
+	}
+	return nil
+}
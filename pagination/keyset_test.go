@@ -0,0 +1,66 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrder(t *testing.T) {
+	keys := []Key{{Column: "name"}, {Column: "created_at", Desc: true}, {Column: "id"}}
+	if got, want := Order(keys), "name,created_at desc,id"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	keys := []Key{{Column: "name", Value: "b"}, {Column: "age", Desc: true, Value: 3}, {Column: "id", Value: 7}}
+	where, args, err := Where(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "(name > ?) OR (name = ? AND age < ?) OR (name = ? AND age = ? AND id > ?)"; where != want {
+		t.Errorf("got %q; want %q", where, want)
+	}
+	if want := []interface{}{"b", "b", 3, "b", 3, 7}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got %v; want %v", args, want)
+	}
+}
+
+func TestWhereUnordered(t *testing.T) {
+	var deletedAt *time.Time
+	for _, value := range []interface{}{nil, deletedAt, map[string]string{"a": "b"}, []int64{1}} {
+		if _, _, err := Where([]Key{{Column: "a", Value: value}, {Column: "id", Value: 7}}); err == nil {
+			t.Errorf("expected error paging by %#v", value)
+		}
+	}
+	now := time.Now()
+	for _, value := range []interface{}{&now, []byte("a"), now} {
+		if _, _, err := Where([]Key{{Column: "a", Value: value}}); err != nil {
+			t.Errorf("paging by %#v: %v", value, err)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	type row struct {
+		Id        uint64
+		Name      string
+		CreatedAt time.Time
+	}
+	last := row{Id: 42, Name: "x", CreatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+	token, err := EncodeToken([]Key{{Field: "CreatedAt", Value: last.CreatedAt}, {Field: "Id", Value: last.Id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got row
+	if err := DecodeToken(token, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := (row{Id: 42, CreatedAt: last.CreatedAt}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+	if err := DecodeToken("not a token", &got); err == nil {
+		t.Error("expected error decoding an invalid token")
+	}
+}
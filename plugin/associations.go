@@ -370,8 +370,9 @@ func (p *OrmPlugin) parseManyToMany(msg *generator.Descriptor, ormable *OrmableT
 }
 
 func (p *OrmPlugin) findPrimaryKey(ormable *OrmableType) (string, *Field) {
-	for fieldName, field := range ormable.Fields {
-		if field.GetTag().GetPrimaryKey() {
+	// the first field of a composite key, in a stable order
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		if field := ormable.Fields[fieldName]; field.GetTag().GetPrimaryKey() {
 			return fieldName, field
 		}
 	}
@@ -384,6 +385,16 @@ func (p *OrmPlugin) findPrimaryKey(ormable *OrmableType) (string, *Field) {
 	return "", nil
 }
 
+// primaryKeyFields returns the names of the primary key fields, several for
+// a composite key
+func (p *OrmPlugin) primaryKeyFields(ormable *OrmableType) []string {
+	if pkNames := p.primaryKeyNames(ormable); len(pkNames) > 1 {
+		return pkNames
+	}
+	pkName, _ := p.findPrimaryKey(ormable)
+	return []string{pkName}
+}
+
 func (p *OrmPlugin) hasPrimaryKey(ormable *OrmableType) bool {
 	for _, field := range ormable.Fields {
 		if field.GetTag().GetPrimaryKey() {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
 	jgorm "github.com/jinzhu/gorm"
+	"sort"
 	"strings"
 )
 
@@ -14,19 +15,31 @@ func (p *OrmPlugin) generateDefaultHandlers(file *generator.FileDescriptor) {
 			p.UsingGoImports(stdCtxImport)
 
 			p.generateCreateHandler(message)
+			p.generateCreateSetHandler(message)
 			// FIXME: Temporary fix for Ormable objects that have no ID field but
 			// have pk.
-			if p.hasPrimaryKey(p.getOrmable(p.TypeName(message))) && p.hasIDField(message) {
+			if p.hasPrimaryKey(p.getOrmable(p.TypeName(message))) && p.hasKeyFields(message) {
 				p.generateReadHandler(message)
 				p.generateDeleteHandler(message)
 				p.generateDeleteSetHandler(message)
 				p.generateStrictUpdateHandler(message)
 				p.generatePatchHandler(message)
 				p.generatePatchSetHandler(message)
+				p.generateUpsertHandler(message)
+				if getMessageOptions(message).GetSoftDelete() {
+					p.generateRestoreHandler(message)
+					p.generatePurgeHandler(message)
+				}
 			}
 
 			p.generateApplyFieldMask(message)
 			p.generateListHandler(message)
+			if p.listHasCount(p.getOrmable(p.TypeName(message))) {
+				p.generateCountHandler(message)
+			}
+			if _, ok := p.getOrmable(p.TypeName(message)).Methods[listStreamService]; ok {
+				p.generateStreamListHandler(message)
+			}
 		}
 	}
 }
@@ -80,6 +93,9 @@ func (p *OrmPlugin) generateCreateHandler(message *generator.Descriptor) {
 	p.P(`if err != nil {`)
 	p.P(`return nil, err`)
 	p.P(`}`)
+	if getMessageOptions(message).GetTimestamps() {
+		p.generateSetTimestamps(orm, "")
+	}
 	create := "Create_"
 	p.generateBeforeHookCall(orm, create)
 	p.P(`if err = db.Create(&ormObj).Error; err != nil {`)
@@ -93,6 +109,143 @@ func (p *OrmPlugin) generateCreateHandler(message *generator.Descriptor) {
 	p.generateAfterHookDef(orm, create)
 }
 
+func (p *OrmPlugin) generateCreateSetHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	orm := p.getOrmable(typeName)
+	if reason := p.createSetUnsupported(orm); reason != "" {
+		p.P(`// Cannot autogen DefaultCreate`, typeName, `Set: `, reason, `.`)
+		p.P()
+		return
+	}
+	gormDB := p.Import(gormImport)
+	p.P(`// DefaultCreate`, typeName, `Set executes a gorm create call of the objects in batches of batchSize`)
+	p.P(`// rows (100 if less than 1) inside one transaction`)
+	p.P(`func DefaultCreate`, typeName, `Set(ctx context.Context, in []*`,
+		typeName, `, db *`, gormDB, `.DB, batchSize int) ([]*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`if len(in) == 0 {`)
+	p.P(`return []*`, typeName, `{}, nil`)
+	p.P(`}`)
+	p.P(`if batchSize < 1 {`)
+	p.P(`batchSize = 100`)
+	p.P(`}`)
+	p.P(`ormObjs := make([]`, orm.Name, `, 0, len(in))`)
+	p.P(`for _, obj := range in {`)
+	p.P(`ormObj, err := obj.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	if getMessageOptions(message).GetTimestamps() {
+		p.generateSetTimestamps(orm, "")
+	}
+	p.P(`ormObjs = append(ormObjs, ormObj)`)
+	p.P(`}`)
+	p.P(`pbResponse := make([]*`, typeName, `, 0, len(ormObjs))`)
+	p.P(`create := func(db *`, gormDB, `.DB) error {`)
+	p.P(`var err error`)
+	p.P(`if hook, ok := (interface{}(&`, orm.Name, `{})).(`, orm.Name, `WithBeforeCreateSet); ok {`)
+	p.P(`if db, err = hook.BeforeCreateSet(ctx, in, db); err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`}`)
+	if p.gormVersion == GORM_V2 {
+		p.P(`if err = db.CreateInBatches(&ormObjs, batchSize).Error; err != nil {`)
+	} else {
+		p.P(`if err = `, p.Import(batchImport), `.Create(db, &ormObjs, batchSize); err != nil {`)
+	}
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`for _, ormObj := range ormObjs {`)
+	p.P(`pbObj, err := ormObj.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`pbResponse = append(pbResponse, &pbObj)`)
+	p.P(`}`)
+	p.P(`if hook, ok := (interface{}(&`, orm.Name, `{})).(`, orm.Name, `WithAfterCreateSet); ok {`)
+	p.P(`return hook.AfterCreateSet(ctx, pbResponse, db)`)
+	p.P(`}`)
+	p.P(`return nil`)
+	p.P(`}`)
+	p.generateTransactionCall("create")
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return pbResponse, nil`)
+	p.P(`}`)
+	p.P(`type `, orm.Name, `WithBeforeCreateSet interface {`)
+	p.P(`BeforeCreateSet(context.Context, []*`, orm.OriginName, `, *`, gormDB, `.DB) (*`, gormDB, `.DB, error)`)
+	p.P(`}`)
+	p.P(`type `, orm.Name, `WithAfterCreateSet interface {`)
+	p.P(`AfterCreateSet(context.Context, []*`, orm.OriginName, `, *`, gormDB, `.DB) error`)
+	p.P(`}`)
+}
+
+// createSetUnsupported returns why DefaultCreateSet cannot be generated for
+// the ormable, if so
+func (p *OrmPlugin) createSetUnsupported(ormable *OrmableType) string {
+	if p.gormVersion == GORM_V2 {
+		return ""
+	}
+	for _, field := range ormable.Fields {
+		if field.GetHasMany() != nil || field.GetHasOne() != nil || field.GetManyToMany() != nil || field.GetBelongsTo() != nil {
+			return "GORM v1 cannot create objects with associations in batches, it needs GORM v2"
+		}
+	}
+	return ""
+}
+
+// inTransaction returns the condition telling whether db is in a transaction
+func (p *OrmPlugin) inTransaction() string {
+	if p.gormVersion == GORM_V2 {
+		return fmt.Sprint(`_, ok := db.Statement.ConnPool.(`, p.Import(gormImport), `.TxCommitter); ok`)
+	}
+	return fmt.Sprint(`_, ok := db.CommonDB().(*`, p.Import(stdSqlImport), `.Tx); ok`)
+}
+
+// generateOwnTransaction makes a handler of an ormable with OwnTransactions
+// call itself in a new transaction when db is not in one. call is the call of
+// the handler with tx as db, resType the type of its result if it has one
+func (p *OrmPlugin) generateOwnTransaction(ormable *OrmableType, resType, call string) {
+	if !ormable.OwnTransactions {
+		return
+	}
+	p.P(`if `, strings.TrimSuffix(p.inTransaction(), `; ok`), `; !ok {`)
+	if resType == "" {
+		p.P(`return db.Transaction(func(tx *`, p.Import(gormImport), `.DB) error {`)
+		p.P(`return `, call)
+		p.P(`})`)
+		p.P(`}`)
+		return
+	}
+	p.P(`var res `, resType)
+	p.P(`err := db.Transaction(func(tx *`, p.Import(gormImport), `.DB) error {`)
+	p.P(`var err error`)
+	p.P(`res, err = `, call)
+	p.P(`return err`)
+	p.P(`})`)
+	p.P(`return res, err`)
+	p.P(`}`)
+}
+
+// generateTransactionCall runs fn in a transaction and opens the block
+// handling its error
+func (p *OrmPlugin) generateTransactionCall(fn string) {
+	if p.gormVersion == GORM_V2 {
+		p.P(`if err := db.Transaction(`, fn, `); err != nil {`)
+		return
+	}
+	// a transaction started by the caller cannot be nested
+	p.P(`var err error`)
+	p.P(`if `, p.inTransaction(), ` {`)
+	p.P(`err = `, fn, `(db)`)
+	p.P(`} else {`)
+	p.P(`err = db.Transaction(`, fn, `)`)
+	p.P(`}`)
+	p.P(`if err != nil {`)
+}
+
 func (p *OrmPlugin) generateReadHandler(message *generator.Descriptor) {
 	typeName := p.TypeName(message)
 	ormable := p.getOrmable(typeName)
@@ -113,12 +266,7 @@ func (p *OrmPlugin) generateReadHandler(message *generator.Descriptor) {
 	p.P(`if err != nil {`)
 	p.P(`return nil, err`)
 	p.P(`}`)
-	k, f := p.findPrimaryKey(ormable)
-	if strings.Contains(f.Type, "*") {
-		p.P(`if ormObj.`, k, ` == nil || *ormObj.`, k, ` == `, p.guessZeroValue(f.Type), ` {`)
-	} else {
-		p.P(`if ormObj.`, k, ` == `, p.guessZeroValue(f.Type), ` {`)
-	}
+	p.P(`if `, p.emptyKeyCondition(ormable, "ormObj"), ` {`)
 	p.P(`return nil, `, p.Import(gerrorsImport), `.EmptyIdError`)
 	p.P(`}`)
 
@@ -212,7 +360,7 @@ func (p *OrmPlugin) generateApplyFieldMask(message *generator.Descriptor) {
 	hasNested := false
 	for _, field := range message.GetField() {
 		fieldType, _ := p.GoType(message, field)
-		if field.IsMessage() && !isSpecialType(fieldType) && !field.IsRepeated() {
+		if field.IsMessage() && !isSpecialType(fieldType) && wellKnownMessage(field) == "" && !field.IsRepeated() {
 			p.P(`var updated`, generator.CamelCase(field.GetName()), ` bool`)
 			hasNested = true
 		} else if strings.HasSuffix(fieldType, protoTypeJSON) {
@@ -228,8 +376,9 @@ func (p *OrmPlugin) generateApplyFieldMask(message *generator.Descriptor) {
 	for _, field := range message.GetField() {
 		ccName := generator.CamelCase(field.GetName())
 		fieldType, _ := p.GoType(message, field)
-		//  for ormable message, do recursive patching
-		if field.IsMessage() && p.isOrmable(fieldType) && !field.IsRepeated() {
+		if ormType, _, _ := p.mapType(field); ormType != "" {
+			p.generateMapFieldMask(field)
+		} else if field.IsMessage() && p.isOrmable(fieldType) && !field.IsRepeated() { //  for ormable message, do recursive patching
 			p.UsingGoImports(stdStringsImport)
 			p.P(`if !updated`, ccName, ` && strings.HasPrefix(f, prefix+"`, ccName, `.") {`)
 			p.P(`updated`, ccName, ` = true`)
@@ -260,7 +409,7 @@ func (p *OrmPlugin) generateApplyFieldMask(message *generator.Descriptor) {
 			p.P(`patchee.`, ccName, ` = patcher.`, ccName)
 			p.P(`continue`)
 			p.P(`}`)
-		} else if field.IsMessage() && !isSpecialType(fieldType) && !field.IsRepeated() {
+		} else if field.IsMessage() && !isSpecialType(fieldType) && wellKnownMessage(field) == "" && !field.IsRepeated() {
 			p.UsingGoImports(stdStringsImport)
 			p.P(`if !updated`, ccName, ` && strings.HasPrefix(f, prefix+"`, ccName, `.") {`)
 			p.P(`if patcher.`, ccName, ` == nil {`)
@@ -308,6 +457,84 @@ func (p *OrmPlugin) generateApplyFieldMask(message *generator.Descriptor) {
 	p.P()
 }
 
+// hasKeyFields reports whether the message carries its primary key, the id
+// field or every field of a composite key
+func (p *OrmPlugin) hasKeyFields(message *generator.Descriptor) bool {
+	if len(p.primaryKeyFields(p.getOrmable(p.TypeName(message)))) > 1 {
+		return p.hasCompositeKey(message)
+	}
+	return p.hasIDField(message)
+}
+
+// hasCompositeKey reports whether the primary key of the message spans
+// several fields of the proto message, which then identify its objects
+// instead of the id field
+func (p *OrmPlugin) hasCompositeKey(message *generator.Descriptor) bool {
+	pkNames := p.primaryKeyFields(p.getOrmable(p.TypeName(message)))
+	if len(pkNames) < 2 {
+		return false
+	}
+	for _, pkName := range pkNames {
+		found := false
+		for _, field := range message.GetField() {
+			found = found || generator.CamelCase(field.GetName()) == pkName
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// emptyKeyCondition returns the condition telling whether a primary key
+// field of the ORM object obj is not set
+func (p *OrmPlugin) emptyKeyCondition(ormable *OrmableType, obj string) string {
+	var conds []string
+	for _, pkName := range p.primaryKeyFields(ormable) {
+		pk := ormable.Fields[pkName]
+		if strings.Contains(pk.Type, "*") {
+			conds = append(conds, fmt.Sprint(obj, `.`, pkName, ` == nil || *`, obj, `.`, pkName, ` == `, p.guessZeroValue(pk.Type)))
+		} else {
+			conds = append(conds, fmt.Sprint(obj, `.`, pkName, ` == `, p.guessZeroValue(pk.Type)))
+		}
+	}
+	return strings.Join(conds, " || ")
+}
+
+// keyLiteral returns the fields of a proto object literal identifying the
+// same row as the proto object obj
+func (p *OrmPlugin) keyLiteral(ormable *OrmableType, obj string) string {
+	pkNames := p.primaryKeyFields(ormable)
+	if len(pkNames) < 2 {
+		return fmt.Sprint(`Id: `, obj, `.GetId()`)
+	}
+	var fields []string
+	for _, pkName := range pkNames {
+		fields = append(fields, fmt.Sprint(pkName, `: `, obj, `.Get`, pkName, `()`))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// keyWhere returns the condition selecting the row of the ORM object obj by
+// its primary key, and the arguments of the condition
+func (p *OrmPlugin) keyWhere(ormable *OrmableType, obj string) (string, string) {
+	var conds, args []string
+	for _, pkName := range p.primaryKeyFields(ormable) {
+		conds = append(conds, columnName(pkName, ormable.Fields[pkName])+" = ?")
+		args = append(args, obj+"."+pkName)
+	}
+	return strings.Join(conds, " AND "), strings.Join(args, ", ")
+}
+
+// keyOrder returns the ORDER BY clause sorting the rows by primary key
+func (p *OrmPlugin) keyOrder(ormable *OrmableType) string {
+	var columns []string
+	for _, pkName := range p.primaryKeyFields(ormable) {
+		columns = append(columns, columnName(pkName, ormable.Fields[pkName]))
+	}
+	return strings.Join(columns, ",")
+}
+
 func (p *OrmPlugin) hasIDField(message *generator.Descriptor) bool {
 	for _, field := range message.GetField() {
 		if strings.ToLower(field.GetName()) == "id" {
@@ -328,7 +555,7 @@ func (p *OrmPlugin) generatePatchHandler(message *generator.Descriptor) {
 		isMultiAccount = true
 	}
 
-	if isMultiAccount && !p.hasIDField(message) {
+	if isMultiAccount && !p.hasKeyFields(message) {
 		p.P(fmt.Sprintf("// Cannot autogen DefaultPatch%s: this is a multi-account table without an \"id\" field in the message.\n", typeName))
 		return
 	}
@@ -344,9 +571,9 @@ func (p *OrmPlugin) generatePatchHandler(message *generator.Descriptor) {
 	p.P(`var err error`)
 	p.generateBeforePatchHookCall(ormable, "Read")
 	if p.readHasFieldSelection(ormable) {
-		p.P(`pbReadRes, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{Id: in.GetId()}, db, nil)`)
+		p.P(`pbReadRes, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{`, p.keyLiteral(ormable, "in"), `}, db, nil)`)
 	} else {
-		p.P(`pbReadRes, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{Id: in.GetId()}, db)`)
+		p.P(`pbReadRes, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{`, p.keyLiteral(ormable, "in"), `}, db)`)
 	}
 
 	p.P(`if err != nil {`)
@@ -359,6 +586,10 @@ func (p *OrmPlugin) generatePatchHandler(message *generator.Descriptor) {
 	p.P(`if _, err := DefaultApplyFieldMask`, typeName, `(ctx, &pbObj, in, updateMask, "", db); err != nil {`)
 	p.P(`return nil, err`)
 	p.P(`}`)
+	if ormable.VersionField != "" {
+		// the update is checked against the version the client has read
+		p.P(`pbObj.`, ormable.VersionField, ` = in.`, ormable.VersionField)
+	}
 
 	p.generateBeforePatchHookCall(ormable, "Save")
 	p.P(`pbResponse, err := DefaultStrictUpdate`, typeName, `(ctx, &pbObj, db)`)
@@ -414,7 +645,7 @@ func (p *OrmPlugin) generatePatchSetHandler(message *generator.Descriptor) {
 		isMultiAccount = true
 	}
 
-	if isMultiAccount && !p.hasIDField(message) {
+	if isMultiAccount && !p.hasKeyFields(message) {
 		p.P(fmt.Sprintf("// Cannot autogen DefaultPatchSet%s: this is a multi-account table without an \"id\" field in the message.\n", typeName))
 		return
 	}
@@ -427,6 +658,14 @@ func (p *OrmPlugin) generatePatchSetHandler(message *generator.Descriptor) {
 	p.P(`return nil, fmt.Errorf(`, p.Import(gerrorsImport), `.BadRepeatedFieldMaskTpl, len(updateMasks), len(objects))`)
 	p.P(`}`)
 	p.P(``)
+	// the rows are read back by a single key column
+	batched := !p.hasChildAssociations(p.getOrmable(typeName)) && !p.hasCompositeKey(message)
+	if batched {
+		p.P(`if columns, ok := patchSet`, typeName, `Columns(updateMasks); ok {`)
+		p.P(`return defaultPatchSet`, typeName, `InBatches(ctx, objects, updateMasks[0], columns, db)`)
+		p.P(`}`)
+	}
+	p.generateOwnTransaction(p.getOrmable(typeName), "[]*"+typeName, fmt.Sprint(`DefaultPatchSet`, typeName, `(ctx, objects, updateMasks, tx)`))
 	p.P(`results := make([]*`, typeName, `, 0, len(objects))`)
 	p.P(`for i, patcher := range objects {`)
 	p.P(`pbResponse, err := DefaultPatch`, typeName, `(ctx, patcher, updateMasks[i], db)`)
@@ -439,52 +678,470 @@ func (p *OrmPlugin) generatePatchSetHandler(message *generator.Descriptor) {
 	p.P(``)
 	p.P(`return results, nil`)
 	p.P(`}`)
+	if batched {
+		p.generatePatchSetColumns(message)
+		p.generatePatchSetInBatches(message)
+	}
 }
 
-func (p *OrmPlugin) generateDeleteHandler(message *generator.Descriptor) {
+// hasChildAssociations reports whether DefaultStrictUpdate of the ormable
+// has to update the children of its associations
+func (p *OrmPlugin) hasChildAssociations(ormable *OrmableType) bool {
+	for _, field := range ormable.Fields {
+		if field.GetHasMany() != nil || field.GetHasOne() != nil || field.GetManyToMany() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// generatePatchSetColumns generates the function returning the columns
+// DefaultPatchSet can update in batches, when all the update masks are the
+// same, only hold columns and no per-object patch hook is implemented
+func (p *OrmPlugin) generatePatchSetColumns(message *generator.Descriptor) {
 	typeName := p.TypeName(message)
-	p.P(`func DefaultDelete`, typeName, `(ctx context.Context, in *`,
-		typeName, `, db *`, p.Import(gormImport), `.DB) error {`)
+	ormable := p.getOrmable(typeName)
+	timestamps := getMessageOptions(message).GetTimestamps()
+	pkName, _ := p.findPrimaryKey(ormable)
+	// columns set by the handler or never updated
+	managed := map[string]bool{pkName: true, "AccountID": true, ormable.VersionField: true}
+	if timestamps {
+		managed["CreatedAt"], managed["UpdatedAt"] = true, true
+	}
+
+	p.P(`// patchSet`, typeName, `Columns returns the columns DefaultPatchSet`, typeName, ` updates in batches`)
+	p.P(`func patchSet`, typeName, `Columns(updateMasks []*`, p.Import(fmImport), `.FieldMask) ([]string, bool) {`)
+	p.P(`switch interface{}(&`, typeName, `{}).(type) {`)
+	p.P(`case `, ormable.OriginName, `WithBeforePatchRead, `, ormable.OriginName, `WithBeforePatchApplyFieldMask, `,
+		ormable.OriginName, `WithBeforePatchSave, `, ormable.OriginName, `WithAfterPatchSave:`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`switch interface{}(&`, ormable.Name, `{}).(type) {`)
+	p.P(`case `, ormable.Name, `WithBeforeStrictUpdateCleanup, `, ormable.Name, `WithBeforeStrictUpdateSave, `, ormable.Name, `WithAfterStrictUpdateSave:`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`if len(updateMasks) == 0 || len(updateMasks[0].GetPaths()) == 0 {`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`paths := updateMasks[0].GetPaths()`)
+	p.P(`for _, updateMask := range updateMasks[1:] {`)
+	p.P(`if len(updateMask.GetPaths()) != len(paths) {`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`for i, path := range updateMask.GetPaths() {`)
+	p.P(`if path != paths[i] {`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`}`)
+	var extra []string
+	if timestamps {
+		extra = append(extra, fmt.Sprintf("%q", columnName("UpdatedAt", ormable.Fields["UpdatedAt"])))
+	}
+	if ormable.VersionField != "" {
+		extra = append(extra, fmt.Sprintf("%q", columnName(ormable.VersionField, ormable.Fields[ormable.VersionField])))
+	}
+	p.P(`columns := []string{`, strings.Join(extra, ", "), `}`)
+	p.P(`for _, path := range paths {`)
+	p.P(`switch path {`)
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		if !isColumnField(field) || managed[fieldName] {
+			continue
+		}
+		p.P(`case "`, fieldName, `":`)
+		p.P(`columns = append(columns, "`, columnName(fieldName, field), `")`)
+	}
+	p.P(`default:`)
+	p.P(`return nil, false`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`return columns, true`)
+	p.P(`}`)
+	p.P()
+}
+
+// generatePatchSetInBatches generates the function patching all the objects
+// with the same update mask, reading their rows in one query and writing
+// them back in batches inside one transaction
+func (p *OrmPlugin) generatePatchSetInBatches(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	gerrors := p.Import(gerrorsImport)
+	pkName, pk := p.findPrimaryKey(ormable)
+	keyType, key := pk.Type, "ormIn."+pkName
+	if strings.HasPrefix(keyType, "*") {
+		keyType, key = strings.TrimPrefix(keyType, "*"), "*"+key
+	}
+
+	p.P(`func defaultPatchSet`, typeName, `InBatches(ctx context.Context, objects []*`, typeName, `, updateMask *`, p.Import(fmImport),
+		`.FieldMask, columns []string, db *`, p.Import(gormImport), `.DB) ([]*`, typeName, `, error) {`)
+	p.P(`var itemErrs `, gerrors, `.SetError`)
+	p.P(`keys := make([]`, keyType, `, len(objects))`)
+	p.P(`seen := make(map[`, keyType, `]int, len(objects))`)
+	p.P(`for i, in := range objects {`)
 	p.P(`if in == nil {`)
-	p.P(`return `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: `, gerrors, `.NilArgumentError})`)
+	p.P(`continue`)
 	p.P(`}`)
-	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`ormIn, err := in.ToORM(ctx)`)
 	p.P(`if err != nil {`)
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: err})`)
+	p.P(`continue`)
+	p.P(`}`)
+	if strings.HasPrefix(pk.Type, "*") {
+		p.P(`if ormIn.`, pkName, ` == nil || *ormIn.`, pkName, ` == `, p.guessZeroValue(pk.Type), ` {`)
+	} else {
+		p.P(`if ormIn.`, pkName, ` == `, p.guessZeroValue(pk.Type), ` {`)
+	}
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: `, gerrors, `.EmptyIdError})`)
+	p.P(`continue`)
+	p.P(`}`)
+	// one statement cannot update a row twice
+	p.P(`if j, ok := seen[`, key, `]; ok {`)
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: fmt.Errorf("duplicate of object %d", j)})`)
+	p.P(`continue`)
+	p.P(`}`)
+	p.P(`seen[`, key, `] = i`)
+	p.P(`keys[i] = `, key)
+	p.P(`}`)
+	p.P(`if itemErrs != nil {`)
+	p.P(`return nil, itemErrs`)
+	p.P(`}`)
+	p.P(`results := make([]*`, typeName, `, len(objects))`)
+	p.P(`patch := func(db *`, p.Import(gormImport), `.DB) error {`)
+	if getMessageOptions(message).GetMultiAccount() {
+		p.P(`accountID, err := `, p.Import(authImport), `.GetAccountID(ctx, nil)`)
+		p.P(`if err != nil {`)
+		p.P(`return err`)
+		p.P(`}`)
+		p.P(`db = db.Where(map[string]interface{}{"account_id": accountID})`)
+	}
+	p.P(`rows := []`, ormable.Name, `{}`)
+	p.P(`if err := db.`, p.lockForUpdate(), `Where("`, columnName(pkName, pk), ` IN (?)", keys).Find(&rows).Error; err != nil {`)
 	p.P(`return err`)
 	p.P(`}`)
+	p.P(`byKey := make(map[`, keyType, `]*`, ormable.Name, `, len(rows))`)
+	p.P(`for i := range rows {`)
+	rowKey := "rows[i]." + pkName
+	if strings.HasPrefix(pk.Type, "*") {
+		rowKey = "*" + rowKey
+	}
+	p.P(`byKey[`, rowKey, `] = &rows[i]`)
+	p.P(`}`)
+	p.P(`ormObjs := make([]`, ormable.Name, `, 0, len(objects))`)
+	p.P(`for i, in := range objects {`)
+	p.P(`row, ok := byKey[keys[i]]`)
+	p.P(`if !ok {`)
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: `, p.Import(gormImport), `.ErrRecordNotFound})`)
+	p.P(`continue`)
+	p.P(`}`)
+	p.P(`pbObj, err := row.ToPB(ctx)`)
+	p.P(`if err == nil {`)
+	p.P(`_, err = DefaultApplyFieldMask`, typeName, `(ctx, &pbObj, in, updateMask, "", db)`)
+	p.P(`}`)
+	if ormable.VersionField != "" {
+		p.P(`pbObj.`, ormable.VersionField, ` = in.`, ormable.VersionField)
+	}
+	p.P(`var ormObj `, ormable.Name)
+	p.P(`if err == nil {`)
+	p.P(`ormObj, err = pbObj.ToORM(ctx)`)
+	p.P(`}`)
+	p.P(`if err != nil {`)
+	p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: err})`)
+	p.P(`continue`)
+	p.P(`}`)
+	if ormable.VersionField != "" {
+		p.P(`if ormObj.`, ormable.VersionField, ` != row.`, ormable.VersionField, ` {`)
+		p.P(`itemErrs = append(itemErrs, &`, gerrors, `.ItemError{Index: i, Err: `, gerrors, `.ConflictError})`)
+		p.P(`continue`)
+		p.P(`}`)
+		p.P(`ormObj.`, ormable.VersionField, `++`)
+	}
+	if getMessageOptions(message).GetTimestamps() {
+		p.generateSetTimestamps(ormable, "row")
+	}
+	p.P(`ormObjs = append(ormObjs, ormObj)`)
+	p.P(`}`)
+	p.P(`if itemErrs != nil {`)
+	p.P(`return itemErrs`)
+	p.P(`}`)
+	// the rows exist, so that the inserts only update the masked columns
+	if p.gormVersion == GORM_V2 {
+		clause := p.Import(gormClauseImport)
+		p.P(`onConflict := `, clause, `.OnConflict{Columns: []`, clause, `.Column{{Name: "`, columnName(pkName, pk),
+			`"}}, DoUpdates: `, clause, `.AssignmentColumns(columns)}`)
+		p.P(`if err := db.Omit(`, clause, `.Associations).Clauses(onConflict).CreateInBatches(&ormObjs, 100).Error; err != nil {`)
+	} else {
+		p.generateInsertOption([]string{columnName(pkName, pk)})
+		p.P(`if err := `, p.Import(batchImport), `.Create(db.Set("gorm:insert_option", onConflict), &ormObjs, 100); err != nil {`)
+	}
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`for i := range ormObjs {`)
+	p.P(`pbResponse, err := ormObjs[i].ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`results[i] = &pbResponse`)
+	p.P(`}`)
+	p.P(`return nil`)
+	p.P(`}`)
+	p.generateTransactionCall("patch")
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return results, nil`)
+	p.P(`}`)
+	p.P()
+}
+
+func (p *OrmPlugin) generateUpsertHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
 	ormable := p.getOrmable(typeName)
-	pkName, pk := p.findPrimaryKey(ormable)
-	if strings.Contains(pk.Type, "*") {
-		p.P(`if ormObj.`, pkName, ` == nil || *ormObj.`, pkName, ` == `, p.guessZeroValue(pk.Type), ` {`)
+	target, reason := p.upsertTarget(ormable)
+	if reason != "" {
+		p.P(`// Cannot autogen DefaultUpsert`, typeName, `: `, reason, `.`)
+		p.P()
+		return
+	}
+	timestamps := getMessageOptions(message).GetTimestamps()
+	// the conflict target, the key and the owner of an existing row are kept
+	kept := map[string]bool{"CreatedAt": true, "AccountID": true}
+	for _, pkName := range p.primaryKeyFields(ormable) {
+		kept[pkName] = true
+	}
+	var updatable []string
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		column := columnName(fieldName, field)
+		if !isColumnField(field) || kept[fieldName] || inStrings(target, column) {
+			continue
+		}
+		updatable = append(updatable, fieldName)
+	}
+
+	p.UsingGoImports(stdFmtImport)
+	p.P(`// DefaultUpsert`, typeName, ` inserts the object, or updates the columns in the update mask (all of them`)
+	p.P(`// if empty) of the row with the same `, strings.Join(target, ", "), ` in one statement`)
+	p.P(`func DefaultUpsert`, typeName, `(ctx context.Context, in *`, typeName, `, updateMask *`, p.Import(fmImport),
+		`.FieldMask, db *`, p.Import(gormImport), `.DB) (*`, typeName, `, error) {`)
+	p.P(`if in == nil {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	if timestamps {
+		p.generateSetTimestamps(ormable, "")
+	}
+	var columns []string
+	for _, fieldName := range updatable {
+		columns = append(columns, fmt.Sprintf("%q", columnName(fieldName, ormable.Fields[fieldName])))
+	}
+	if len(columns) == 0 {
+		// a no-op update, so that the existing row is not an error
+		columns = []string{fmt.Sprintf("%q", target[0])}
+	}
+	p.P(`columns := []string{`, strings.Join(columns, ", "), `}`)
+	p.P(`if len(updateMask.GetPaths()) > 0 {`)
+	if timestamps {
+		p.P(`columns = []string{"`, columnName("UpdatedAt", ormable.Fields["UpdatedAt"]), `"}`)
 	} else {
-		p.P(`if ormObj.`, pkName, ` == `, p.guessZeroValue(pk.Type), `{`)
+		p.P(`columns = nil`)
 	}
-	p.P(`return `, p.Import(gerrorsImport), `.EmptyIdError`)
+	p.P(`for _, f := range updateMask.GetPaths() {`)
+	p.P(`switch f {`)
+	for _, fieldName := range updatable {
+		if timestamps && fieldName == "UpdatedAt" {
+			continue
+		}
+		p.P(`case "`, fieldName, `":`)
+		p.P(`columns = append(columns, "`, columnName(fieldName, ormable.Fields[fieldName]), `")`)
+	}
+	p.P(`default:`)
+	p.P(`return nil, fmt.Errorf("cannot upsert %s", f)`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`}`)
+	upsert := "Upsert"
+	p.generateBeforeHookCall(ormable, upsert)
+	if p.gormVersion == GORM_V2 {
+		clause := p.Import(gormClauseImport)
+		var targetColumns []string
+		for _, column := range target {
+			targetColumns = append(targetColumns, fmt.Sprintf("{Name: %q}", column))
+		}
+		p.P(`onConflict := `, clause, `.OnConflict{Columns: []`, clause, `.Column{`, strings.Join(targetColumns, ", "),
+			`}, DoUpdates: `, clause, `.AssignmentColumns(columns)}`)
+		p.P(`if err = db.Clauses(onConflict).Create(&ormObj).Error; err != nil {`)
+	} else {
+		p.generateInsertOption(target)
+		p.P(`if err = db.Set("gorm:insert_option", onConflict).Create(&ormObj).Error; err != nil {`)
+	}
+	p.P(`return nil, err`)
+	p.P(`}`)
+	// read the stored row back, the keys returned by an update are not
+	// reliable on every engine and the kept columns have to be returned
+	var conditions, args []string
+	for _, column := range target {
+		conditions = append(conditions, column+" = ?")
+	}
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		if field := ormable.Fields[fieldName]; isColumnField(field) && inStrings(target, columnName(fieldName, field)) {
+			args = append(args, "ormObj."+fieldName)
+		}
+	}
+	read := "db"
+	if ormable.SoftDelete {
+		read = "db.Unscoped()"
+	}
+	p.P(`stored := `, ormable.Name, `{}`)
+	p.P(`if err = `, read, `.Where("`, strings.Join(conditions, " AND "), `", `, strings.Join(args, ", "), `).First(&stored).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`ormObj = stored`)
+	p.generateAfterHookCall(ormable, upsert)
+	p.P(`pbResponse, err := ormObj.ToPB(ctx)`)
+	p.P(`return &pbResponse, err`)
 	p.P(`}`)
-	p.generateBeforeDeleteHookCall(ormable)
+	p.generateBeforeHookDef(ormable, upsert)
+	p.generateAfterHookDef(ormable, upsert)
+}
+
+// generateInsertOption generates the onConflict GORM v1 insert option
+// updating the columns of the rows conflicting on the target columns
+func (p *OrmPlugin) generateInsertOption(target []string) {
+	p.UsingGoImports(stdStringsImport)
+	var onConflict, assignment string
+	if p.dbEngine == ENGINE_MYSQL {
+		onConflict = "ON DUPLICATE KEY UPDATE "
+		assignment = fmt.Sprintf("%s = VALUES(%s)", p.quoteIdent("%[1]s"), p.quoteIdent("%[1]s"))
+	} else {
+		onConflict = fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET ", p.quoteIdents(target))
+		assignment = fmt.Sprintf("%s = EXCLUDED.%s", p.quoteIdent("%[1]s"), p.quoteIdent("%[1]s"))
+	}
+	p.P(`assignments := make([]string, 0, len(columns))`)
+	p.P(`for _, column := range columns {`)
+	p.P(`assignments = append(assignments, fmt.Sprintf(`, fmt.Sprintf("%q", assignment), `, column))`)
+	p.P(`}`)
+	p.P(`onConflict := `, fmt.Sprintf("%q", onConflict), ` + strings.Join(assignments, ", ")`)
+}
+
+// upsertTarget returns the columns of the conflict target of DefaultUpsert,
+// or why the ormable cannot be upserted
+func (p *OrmPlugin) upsertTarget(ormable *OrmableType) ([]string, string) {
+	if ormable.VersionField != "" {
+		return nil, "versioned objects are updated by DefaultStrictUpdate"
+	}
+	var target []string
+	if ormable.UpsertOn == "" {
+		for _, pkName := range p.primaryKeyFields(ormable) {
+			target = append(target, columnName(pkName, ormable.Fields[pkName]))
+		}
+	} else {
+		for _, index := range p.collectIndexes(ormable) {
+			if index.unique && index.name == ormable.UpsertOn {
+				target = index.columns
+			}
+		}
+		if target == nil {
+			p.Fail("upsert_on", ormable.UpsertOn, "of", ormable.Name, "is not a unique_index.")
+		}
+	}
+	if ormable.MultiAccount && !inStrings(target, columnName("AccountID", ormable.Fields["AccountID"])) {
+		return nil, "the conflict target of a multi-account table must include account_id"
+	}
+	return target, ""
+}
+
+func (p *OrmPlugin) generateDeleteHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	p.P(`func DefaultDelete`, typeName, `(ctx context.Context, in *`,
+		typeName, `, db *`, p.Import(gormImport), `.DB) error {`)
+	ormable := p.getOrmable(typeName)
+	p.generateDeleteSetup(ormable)
+	delete := "Delete_"
+	p.generateBeforeDeleteHookCall(ormable, delete)
 	p.P(`err = db.Where(&ormObj).Delete(&`, ormable.Name, `{}).Error`)
 	p.P(`if err != nil {`)
 	p.P(`return err`)
 	p.P(`}`)
-	p.generateAfterDeleteHookCall(ormable)
+	p.generateAfterDeleteHookCall(ormable, delete)
 	p.P(`return err`)
 	p.P(`}`)
-	delete := "Delete_"
 	p.generateBeforeHookDef(ormable, delete)
 	p.generateAfterHookDef(ormable, delete)
 }
 
-func (p *OrmPlugin) generateBeforeDeleteHookCall(orm *OrmableType) {
-	p.P(`if hook, ok := interface{}(&ormObj).(`, orm.Name, `WithBeforeDelete_); ok {`)
-	p.P(`if db, err = hook.BeforeDelete_(ctx, db); err != nil {`)
+func (p *OrmPlugin) generateBeforeDeleteHookCall(orm *OrmableType, method string) {
+	p.P(`if hook, ok := interface{}(&ormObj).(`, orm.Name, `WithBefore`, method, `); ok {`)
+	p.P(`if db, err = hook.Before`, method, `(ctx, db); err != nil {`)
 	p.P(`return err`)
 	p.P(`}`)
 	p.P(`}`)
 }
 
-func (p *OrmPlugin) generateAfterDeleteHookCall(orm *OrmableType) {
-	p.P(`if hook, ok := interface{}(&ormObj).(`, orm.Name, `WithAfterDelete_); ok {`)
-	p.P(`err = hook.AfterDelete_(ctx, db)`)
+func (p *OrmPlugin) generateAfterDeleteHookCall(orm *OrmableType, method string) {
+	p.P(`if hook, ok := interface{}(&ormObj).(`, orm.Name, `WithAfter`, method, `); ok {`)
+	p.P(`err = hook.After`, method, `(ctx, db)`)
+	p.P(`}`)
+}
+
+// generateRestoreHandler generates DefaultRestore<Type> which clears the
+// deletion mark of a soft deleted row
+func (p *OrmPlugin) generateRestoreHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	p.P(`// DefaultRestore`, typeName, ` clears the deletion mark of a soft deleted row`)
+	p.P(`func DefaultRestore`, typeName, `(ctx context.Context, in *`,
+		typeName, `, db *`, p.Import(gormImport), `.DB) error {`)
+	p.generateDeleteSetup(ormable)
+	p.generateBeforeDeleteHookCall(ormable, "Restore")
+	p.P(`err = db.Unscoped().Model(&`, ormable.Name, `{}).Where(&ormObj).Update("deleted_at", nil).Error`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateAfterDeleteHookCall(ormable, "Restore")
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateBeforeHookDef(ormable, "Restore")
+	p.generateAfterHookDef(ormable, "Restore")
+}
+
+// generatePurgeHandler generates DefaultPurge<Type> which permanently deletes
+// a row, whether it is soft deleted or not
+func (p *OrmPlugin) generatePurgeHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	p.P(`// DefaultPurge`, typeName, ` permanently deletes a row, soft deleted or not`)
+	p.P(`func DefaultPurge`, typeName, `(ctx context.Context, in *`,
+		typeName, `, db *`, p.Import(gormImport), `.DB) error {`)
+	p.generateDeleteSetup(ormable)
+	p.generateBeforeDeleteHookCall(ormable, "Purge")
+	p.P(`err = db.Unscoped().Where(&ormObj).Delete(&`, ormable.Name, `{}).Error`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateAfterDeleteHookCall(ormable, "Purge")
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateBeforeHookDef(ormable, "Purge")
+	p.generateAfterHookDef(ormable, "Purge")
+}
+
+// generateDeleteSetup converts the argument of a delete like handler and
+// checks that its primary key is set
+func (p *OrmPlugin) generateDeleteSetup(ormable *OrmableType) {
+	p.P(`if in == nil {`)
+	p.P(`return `, p.Import(gerrorsImport), `.NilArgumentError`)
+	p.P(`}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`if `, p.emptyKeyCondition(ormable, "ormObj"), ` {`)
+	p.P(`return `, p.Import(gerrorsImport), `.EmptyIdError`)
 	p.P(`}`)
 }
 
@@ -495,8 +1152,32 @@ func (p *OrmPlugin) generateDeleteSetHandler(message *generator.Descriptor) {
 	p.P(`if in == nil {`)
 	p.P(`return `, p.Import(gerrorsImport), `.NilArgumentError`)
 	p.P(`}`)
-	p.P(`var err error`)
 	ormable := p.getOrmable(typeName)
+	p.generateOwnTransaction(ormable, "", fmt.Sprint(`DefaultDelete`, typeName, `Set(ctx, in, tx)`))
+	p.P(`var err error`)
+	if p.hasCompositeKey(message) {
+		p.generateDeleteSetByKeys(message)
+	} else {
+		p.generateDeleteSetByIds(message)
+	}
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.generateAfterDeleteSetHookCall(ormable)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`type `, ormable.Name, `WithBeforeDeleteSet interface {`)
+	p.P(`BeforeDeleteSet(context.Context, []*`, ormable.OriginName, `, *`, p.Import(gormImport), `.DB) (*`, p.Import(gormImport), `.DB, error)`)
+	p.P(`}`)
+	p.P(`type `, ormable.Name, `WithAfterDeleteSet interface {`)
+	p.P(`AfterDeleteSet(context.Context, []*`, ormable.OriginName, `, *`, p.Import(gormImport), `.DB) error`)
+	p.P(`}`)
+}
+
+// generateDeleteSetByIds deletes the rows whose primary key is one of the
+// ids of the objects
+func (p *OrmPlugin) generateDeleteSetByIds(message *generator.Descriptor) {
+	ormable := p.getOrmable(p.TypeName(message))
 	pkName, pk := p.findPrimaryKey(ormable)
 	p.P(`keys := []`, pk.Type, `{}`)
 	p.P(`for _, obj := range in {`)
@@ -523,18 +1204,41 @@ func (p *OrmPlugin) generateDeleteSetHandler(message *generator.Descriptor) {
 	} else {
 		p.P(`err = db.Where("`, jgorm.ToDBName(pkName), ` in (?)", keys).Delete(&`, ormable.Name, `{}).Error`)
 	}
+}
+
+// generateDeleteSetByKeys deletes the rows matching all the primary key
+// columns of one of the objects, for a composite key
+func (p *OrmPlugin) generateDeleteSetByKeys(message *generator.Descriptor) {
+	ormable := p.getOrmable(p.TypeName(message))
+	where, args := p.keyWhere(ormable, "ormObj")
+	p.UsingGoImports(stdStringsImport)
+	p.P(`conds := make([]string, 0, len(in))`)
+	p.P(`args := make([]interface{}, 0, len(in)*`, len(p.primaryKeyFields(ormable)), `)`)
+	p.P(`for _, obj := range in {`)
+	p.P(`ormObj, err := obj.ToORM(ctx)`)
 	p.P(`if err != nil {`)
 	p.P(`return err`)
 	p.P(`}`)
-	p.generateAfterDeleteSetHookCall(ormable)
-	p.P(`return err`)
+	p.P(`if `, p.emptyKeyCondition(ormable, "ormObj"), ` {`)
+	p.P(`return `, p.Import(gerrorsImport), `.EmptyIdError`)
 	p.P(`}`)
-	p.P(`type `, ormable.Name, `WithBeforeDeleteSet interface {`)
-	p.P(`BeforeDeleteSet(context.Context, []*`, ormable.OriginName, `, *`, p.Import(gormImport), `.DB) (*`, p.Import(gormImport), `.DB, error)`)
+	p.P(`conds = append(conds, "(`, where, `)")`)
+	p.P(`args = append(args, `, args, `)`)
 	p.P(`}`)
-	p.P(`type `, ormable.Name, `WithAfterDeleteSet interface {`)
-	p.P(`AfterDeleteSet(context.Context, []*`, ormable.OriginName, `, *`, p.Import(gormImport), `.DB) error`)
+	p.generateBeforeDeleteSetHookCall(ormable)
+	p.P(`if len(conds) == 0 {`)
+	p.P(`return nil`)
 	p.P(`}`)
+	p.P(`where := strings.Join(conds, " OR ")`)
+	if getMessageOptions(message).GetMultiAccount() {
+		p.P(`acctId, err := `, p.Import(authImport), `.GetAccountID(ctx, nil)`)
+		p.P(`if err != nil {`)
+		p.P(`return err`)
+		p.P(`}`)
+		p.P(`where = "account_id = ? AND (" + where + ")"`)
+		p.P(`args = append([]interface{}{acctId}, args...)`)
+	}
+	p.P(`err = db.Where(where, args...).Delete(&`, ormable.Name, `{}).Error`)
 }
 
 func (p *OrmPlugin) generateBeforeDeleteSetHookCall(orm *OrmableType) {
@@ -582,6 +1286,10 @@ func (p *OrmPlugin) generateListHandler(message *generator.Descriptor) {
 	} else {
 		fs = "nil"
 	}
+	softDelete := getMessageOptions(message).GetSoftDelete()
+	if softDelete {
+		listSign += `, withDeleted bool`
+	}
 	listSign += fmt.Sprint(`) ([]*`, typeName, `, error) {`)
 	p.P(listSign)
 	p.P(`in := `, typeName, `{}`)
@@ -589,8 +1297,22 @@ func (p *OrmPlugin) generateListHandler(message *generator.Descriptor) {
 	p.P(`if err != nil {`)
 	p.P(`return nil, err`)
 	p.P(`}`)
+	if softDelete {
+		p.P(`if withDeleted {`)
+		p.P(`db = db.Unscoped()`)
+		p.P(`}`)
+	}
+	p.generateQueryFieldsMapping(message, f, s, "nil, ")
 	p.generateBeforeListHookCall(ormable, "ApplyQuery")
-	p.P(`db, err = `, p.Import(tkgormImport), `.ApplyCollectionOperators(ctx, db, &`, ormable.Name, `{}, &`, typeName, `{}, `, f, `,`, s, `,`, pg, `,`, fs, `)`)
+	sorting, paging := s, pg
+	if p.listHasKeysetPagination(ormable) {
+		p.generateKeysetPagination(ormable, s)
+		if s != "nil" {
+			sorting = "sorting"
+		}
+		paging = "paging"
+	}
+	p.P(`db, err = `, p.Import(tkgormImport), `.ApplyCollectionOperators(ctx, db, &`, ormable.Name, `{}, &`, typeName, `{}, `, f, `,`, sorting, `,`, paging, `,`, fs, `)`)
 	p.P(`if err != nil {`)
 	p.P(`return nil, err`)
 	p.P(`}`)
@@ -599,12 +1321,7 @@ func (p *OrmPlugin) generateListHandler(message *generator.Descriptor) {
 
 	// add default ordering by primary key
 	if p.hasPrimaryKey(ormable) {
-		pkName, pk := p.findPrimaryKey(ormable)
-		column := pk.GetTag().GetColumn()
-		if len(column) == 0 {
-			column = jgorm.ToDBName(pkName)
-		}
-		p.P(`db = db.Order("`, column, `")`)
+		p.P(`db = db.Order("`, p.keyOrder(ormable), `")`)
 	}
 
 	p.P(`ormResponse := []`, ormable.Name, `{}`)
@@ -625,6 +1342,348 @@ func (p *OrmPlugin) generateListHandler(message *generator.Descriptor) {
 	p.generateBeforeListHookDef(ormable, "ApplyQuery")
 	p.generateBeforeListHookDef(ormable, "Find")
 	p.generateAfterListHookDef(ormable)
+	p.generateQueryFields(message)
+	if p.listHasKeysetPagination(ormable) {
+		p.generateKeysetKeys(ormable)
+		p.generatePageTokenFunction(message)
+	}
+}
+
+// generateCountHandler generates DefaultCount<Type>, which counts the rows
+// matching the filter of DefaultList<Type> regardless of the pagination
+func (p *OrmPlugin) generateCountHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	hasFiltering := p.listHasFiltering(ormable)
+	softDelete := getMessageOptions(message).GetSoftDelete()
+
+	p.P(`// DefaultCount`, typeName, ` counts the rows matching the filter of DefaultList`, typeName)
+	countSign := fmt.Sprint(`func DefaultCount`, typeName, `(ctx context.Context, db *`, p.Import(gormImport), `.DB`)
+	if hasFiltering {
+		countSign += fmt.Sprint(`, f `, `*`, p.Import(queryImport), `.Filtering`)
+	}
+	if softDelete {
+		countSign += `, withDeleted bool`
+	}
+	countSign += `) (int64, error) {`
+	p.P(countSign)
+	p.P(`in := `, typeName, `{}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return 0, err`)
+	p.P(`}`)
+	if softDelete {
+		p.P(`if withDeleted {`)
+		p.P(`db = db.Unscoped()`)
+		p.P(`}`)
+	}
+	if hasFiltering {
+		p.generateQueryFieldsMapping(message, "f", "nil", "0, ")
+	}
+	p.P(`if hook, ok := interface{}(&ormObj).(`, ormable.Name, `WithBeforeCount); ok {`)
+	if hasFiltering {
+		p.P(`if db, err = hook.BeforeCount(ctx, db, f); err != nil {`)
+	} else {
+		p.P(`if db, err = hook.BeforeCount(ctx, db); err != nil {`)
+	}
+	p.P(`return 0, err`)
+	p.P(`}`)
+	p.P(`}`)
+	if hasFiltering {
+		p.P(`db, err = `, p.Import(tkgormImport), `.ApplyCollectionOperators(ctx, db, &`, ormable.Name, `{}, &`, typeName, `{}, f, nil, nil, nil)`)
+		p.P(`if err != nil {`)
+		p.P(`return 0, err`)
+		p.P(`}`)
+	}
+	p.P(`var count int64`)
+	p.P(`if err := db.Model(&`, ormable.Name, `{}).Where(&ormObj).Count(&count).Error; err != nil {`)
+	p.P(`return 0, err`)
+	p.P(`}`)
+	p.P(`return count, nil`)
+	p.P(`}`)
+	p.P()
+
+	p.P(`type `, ormable.Name, `WithBeforeCount interface {`)
+	hookSign := fmt.Sprint(`BeforeCount(context.Context, *`, p.Import(gormImport), `.DB`)
+	if hasFiltering {
+		hookSign += fmt.Sprint(`, *`, p.Import(queryImport), `.Filtering`)
+	}
+	hookSign += fmt.Sprint(`) (*`, p.Import(gormImport), `.DB, error)`)
+	p.P(hookSign)
+	p.P(`}`)
+}
+
+// generateStreamListHandler generates DefaultStreamList<Type>, which runs the
+// query of DefaultList<Type> and calls send with each row as it is read
+func (p *OrmPlugin) generateStreamListHandler(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	method := ormable.Methods[listStreamService]
+	softDelete := getMessageOptions(message).GetSoftDelete()
+
+	p.P(`// DefaultStreamList`, typeName, ` executes a gorm list call and calls send with each row`)
+	p.P(`// without loading all of them in memory, associations are not preloaded`)
+	listSign := fmt.Sprint(`func DefaultStreamList`, typeName, `(ctx context.Context, db *`, p.Import(gormImport), `.DB`)
+	f, s, pg, fs := "nil", "nil", "nil", "nil"
+	if p.getFiltering(method.inType) != "" {
+		listSign += fmt.Sprint(`, f `, `*`, p.Import(queryImport), `.Filtering`)
+		f = "f"
+	}
+	if p.getSorting(method.inType) != "" {
+		listSign += fmt.Sprint(`, s `, `*`, p.Import(queryImport), `.Sorting`)
+		s = "s"
+	}
+	if p.getPagination(method.inType) != "" {
+		listSign += fmt.Sprint(`, p `, `*`, p.Import(queryImport), `.Pagination`)
+		pg = "p"
+	}
+	if p.getFieldSelection(method.inType) != "" {
+		listSign += fmt.Sprint(`, fs `, `*`, p.Import(queryImport), `.FieldSelection`)
+		fs = "fs"
+	}
+	if softDelete {
+		listSign += `, withDeleted bool`
+	}
+	listSign += fmt.Sprint(`, send func(*`, typeName, `) error) error {`)
+	p.P(listSign)
+	p.P(`in := `, typeName, `{}`)
+	p.P(`ormObj, err := in.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	if softDelete {
+		p.P(`if withDeleted {`)
+		p.P(`db = db.Unscoped()`)
+		p.P(`}`)
+	}
+	p.generateQueryFieldsMapping(message, f, s, "")
+	p.P(`if hook, ok := interface{}(&ormObj).(`, ormable.Name, `WithBeforeStreamList); ok {`)
+	p.P(`if db, err = hook.BeforeStreamList(ctx, db); err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`db, err = `, p.Import(tkgormImport), `.ApplyCollectionOperators(ctx, db, &`, ormable.Name, `{}, &`, typeName, `{}, `, f, `,`, s, `,`, pg, `,`, fs, `)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`db = db.Where(&ormObj)`)
+	if p.hasPrimaryKey(ormable) {
+		p.P(`db = db.Order("`, p.keyOrder(ormable), `")`)
+	}
+	p.P(`rows, err := db.Model(&`, ormable.Name, `{}).Rows()`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`defer rows.Close()`)
+	p.P(`for rows.Next() {`)
+	p.P(`if err := ctx.Err(); err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`var row `, ormable.Name)
+	p.P(`if err := db.ScanRows(rows, &row); err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`pbObj, err := row.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`if err := send(&pbObj); err != nil {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`return rows.Err()`)
+	p.P(`}`)
+	p.P()
+	p.generateBeforeHookDef(ormable, "StreamList")
+}
+
+// queryFields returns the names List requests can filter (or sort) the
+// message by, mapped to the fields of the ORM type, or nil if none of the
+// fields is filterable (sortable) and all of them can be used
+func (p *OrmPlugin) queryFields(message *generator.Descriptor, sort bool) map[string]string {
+	ormable := p.getOrmable(p.TypeName(message))
+	var names map[string]string
+	for _, field := range message.Field {
+		opts := getFieldOptions(field)
+		if (!sort && !opts.GetFilterable()) || (sort && !opts.GetSortable()) {
+			continue
+		}
+		fieldName := generator.CamelCase(field.GetName())
+		if ormField, ok := ormable.Fields[fieldName]; !ok || !isColumnField(ormField) {
+			p.Fail(`field`, field.GetName(), `of`, p.TypeName(message), `is filterable or sortable but is not a column`)
+		}
+		if names == nil {
+			names = map[string]string{}
+		}
+		// the mapped names are accepted too, so that mapping twice is harmless
+		names[fieldName] = fieldName
+		if apiName := opts.GetApiName(); apiName != "" {
+			names[apiName] = fieldName
+		} else {
+			names[field.GetName()] = fieldName
+			names[field.GetJsonName()] = fieldName
+		}
+	}
+	return names
+}
+
+// generateQueryFields generates the maps of the names the List requests of
+// the message can filter and sort by
+func (p *OrmPlugin) generateQueryFields(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	for _, sorting := range []bool{false, true} {
+		names := p.queryFields(message, sorting)
+		if names == nil {
+			continue
+		}
+		varName, verb := `filterFields`, `filter`
+		if sorting {
+			varName, verb = `sortFields`, `sort`
+		}
+		keys := make([]string, 0, len(names))
+		for name := range names {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		p.P()
+		p.P(`// `, varName, typeName, ` maps the names List requests can `, verb, ` `, typeName, ` by to the fields of `, ormable.Name)
+		p.P(`var `, varName, typeName, ` = `, p.Import(fieldmapImport), `.Fields{`)
+		for _, name := range keys {
+			p.P(`"`, name, `": "`, names[name], `",`)
+		}
+		p.P(`}`)
+	}
+}
+
+// generateQueryFieldsMapping checks the fields of the filter f and the
+// sorting s, when not "nil", and replaces them by copies mapped to the fields
+// of the ORM type, leaving the ones of the request as they are
+func (p *OrmPlugin) generateQueryFieldsMapping(message *generator.Descriptor, f, s, ret string) {
+	typeName := p.TypeName(message)
+	query := p.Import(queryImport)
+	if f != "nil" && p.queryFields(message, false) != nil {
+		p.P(`if `, f, ` != nil {`)
+		p.P(f, ` = `, p.Import(protoImport), `.Clone(`, f, `).(*`, query, `.Filtering)`)
+		p.P(`if err := filterFields`, typeName, `.MapFiltering(`, f, `); err != nil {`)
+		p.P(`return `, ret, `err`)
+		p.P(`}`)
+		p.P(`}`)
+	}
+	if s != "nil" && p.queryFields(message, true) != nil {
+		p.P(`if `, s, ` != nil {`)
+		p.P(s, ` = `, p.Import(protoImport), `.Clone(`, s, `).(*`, query, `.Sorting)`)
+		p.P(`for _, c := range `, s, `.GetCriterias() {`)
+		p.P(`if c.Tag, err = sortFields`, typeName, `.MapSortTag(c.GetTag()); err != nil {`)
+		p.P(`return `, ret, `err`)
+		p.P(`}`)
+		p.P(`}`)
+		p.P(`}`)
+	}
+}
+
+// generateKeysetPagination replaces the offset of the pagination by the
+// condition selecting the rows after the page token. An empty token requests
+// the first page unless an offset is given, or the sorting cannot be held by a
+// token. The hooks keep the sorting and the pagination of the request, never
+// a nil one
+func (p *OrmPlugin) generateKeysetPagination(ormable *OrmableType, s string) {
+	pagination := p.Import(paginationImport)
+	query := p.Import(queryImport)
+	if s != "nil" {
+		p.P(`sorting, paging := s, p`)
+	} else {
+		p.P(`paging := p`)
+	}
+	p.P(`if p == nil {`)
+	p.P(`p = &`, query, `.Pagination{}`)
+	p.P(`}`)
+	p.P(`if p.GetPageToken() != "" || p.GetOffset() == 0 {`)
+	p.P(`keys, err := ormObj.keysetKeys(`, s, `)`)
+	p.P(`if err != nil && p.GetPageToken() != "" {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`if err == nil {`)
+	p.P(`if p.GetPageToken() != "" && p.GetPageToken() != `, pagination, `.FirstPageToken {`)
+	p.P(`last := `, ormable.Name, `{}`)
+	p.P(`if err := `, pagination, `.DecodeToken(p.GetPageToken(), &last); err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`lastKeys, err := last.keysetKeys(`, s, `)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`where, args, err := `, pagination, `.Where(lastKeys)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`db = db.Where(where, args...)`)
+	p.P(`}`)
+	p.P(`if p.GetPageToken() != "" {`)
+	p.P(`paging = &`, query, `.Pagination{Limit: p.GetLimit()}`)
+	p.P(`}`)
+	p.P(`db = db.Order(`, pagination, `.Order(keys))`)
+	if s != "nil" {
+		p.P(`sorting = nil`)
+	}
+	p.P(`}`)
+	p.P(`}`)
+}
+
+// generateKeysetKeys generates the method returning the keyset pagination keys
+// of an ORM object, the sort criteria followed by the primary key. Only the
+// columns of ordered types that cannot be NULL can be sort criteria
+func (p *OrmPlugin) generateKeysetKeys(ormable *OrmableType) {
+	pagination := p.Import(paginationImport)
+	query := p.Import(queryImport)
+	p.UsingGoImports(stdFmtImport)
+	p.P(`func (m *`, ormable.Name, `) keysetKeys(s *`, query, `.Sorting) ([]`, pagination, `.Key, error) {`)
+	p.P(`var keys []`, pagination, `.Key`)
+	p.P(`for _, c := range s.GetCriterias() {`)
+	p.P(`desc := c.GetOrder() == `, query, `.SortCriteria_DESC`)
+	p.P(`switch c.GetTag() {`)
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		if !isColumnField(field) || !isOrderedType(field.Type) && field.Type != "bool" {
+			continue
+		}
+		column := columnName(fieldName, field)
+		if column != fieldName {
+			// the tags are the ORM field names when the sorting is mapped
+			p.P(`case "`, column, `", "`, fieldName, `":`)
+		} else {
+			p.P(`case "`, column, `":`)
+		}
+		p.P(`keys = append(keys, `, pagination, `.Key{Field: "`, fieldName, `", Column: "`, column, `", Desc: desc, Value: m.`, fieldName, `})`)
+	}
+	p.P(`default:`)
+	p.P(`return nil, fmt.Errorf("cannot sort by %s with a page token", c.GetTag())`)
+	p.P(`}`)
+	p.P(`}`)
+	for _, pkName := range p.primaryKeyFields(ormable) {
+		p.P(`keys = append(keys, `, pagination, `.Key{Field: "`, pkName, `", Column: "`, columnName(pkName, ormable.Fields[pkName]), `", Value: m.`, pkName, `})`)
+	}
+	p.P(`return keys, nil`)
+	p.P(`}`)
+}
+
+// generatePageTokenFunction generates Default<Type>PageToken, which returns the
+// token of the page following the last row of a keyset paginated List
+func (p *OrmPlugin) generatePageTokenFunction(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	p.P(`// Default`, typeName, `PageToken returns the page token of the rows following last in DefaultList`, typeName)
+	p.P(`func Default`, typeName, `PageToken(ctx context.Context, last *`, typeName, `, s *`, p.Import(queryImport), `.Sorting) (string, error) {`)
+	p.P(`ormObj, err := last.ToORM(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return "", err`)
+	p.P(`}`)
+	p.generateQueryFieldsMapping(message, "nil", "s", `"", `)
+	p.P(`keys, err := ormObj.keysetKeys(s)`)
+	p.P(`if err != nil {`)
+	p.P(`return "", err`)
+	p.P(`}`)
+	p.P(`return `, p.Import(paginationImport), `.EncodeToken(keys)`)
+	p.P(`}`)
 }
 
 func (p *OrmPlugin) generateBeforeListHookDef(orm *OrmableType, suffix string) {
@@ -720,6 +1779,7 @@ func (p *OrmPlugin) generateStrictUpdateHandler(message *generator.Descriptor) {
 	p.P(`if in == nil {`)
 	p.P(`return nil, fmt.Errorf("Nil argument to DefaultStrictUpdate`, typeName, `")`)
 	p.P(`}`)
+	p.generateOwnTransaction(p.getOrmable(typeName), "*"+typeName, fmt.Sprint(`DefaultStrictUpdate`, typeName, `(ctx, in, tx)`))
 	p.P(`ormObj, err := in.ToORM(ctx)`)
 	p.P(`if err != nil {`)
 	p.P(`return nil, err`)
@@ -728,23 +1788,28 @@ func (p *OrmPlugin) generateStrictUpdateHandler(message *generator.Descriptor) {
 		p.generateAccountIdWhereClause()
 	}
 	ormable := p.getOrmable(typeName)
-	if p.gateway {
+	countRows := p.gateway || (ormable.VersionField != "" && p.hasPrimaryKey(ormable))
+	if countRows {
 		p.P(`var count int64`)
 	}
 	if p.hasPrimaryKey(ormable) {
-		pkName, pk := p.findPrimaryKey(ormable)
-		column := pk.GetTag().GetColumn()
-		if len(column) == 0 {
-			column = jgorm.ToDBName(pkName)
-		}
+		where, args := p.keyWhere(ormable, "ormObj")
 		p.P(`lockedRow := &`, typeName, `ORM{}`)
 		var count string
 		var rowsAffected string
-		if p.gateway {
+		if countRows {
 			count = `count = `
 			rowsAffected = `.RowsAffected`
 		}
-		p.P(count+`db.Model(&ormObj).Set("gorm:query_option", "FOR UPDATE").Where("`, column, `=?", ormObj.`, pkName, `).First(lockedRow)`+rowsAffected)
+		p.P(count+`db.Model(&ormObj).`, p.lockForUpdate(), `Where("`, where, `", `, args, `).First(lockedRow)`+rowsAffected)
+		if ormable.VersionField != "" {
+			p.generateVersionCheck(ormable)
+		}
+		if getMessageOptions(message).GetTimestamps() {
+			p.generateSetTimestamps(ormable, "lockedRow")
+		}
+	} else if getMessageOptions(message).GetTimestamps() {
+		p.generateSetTimestamps(ormable, "")
 	}
 	p.generateBeforeHookCall(ormable, "StrictUpdateCleanup")
 	p.handleChildAssociations(message)
@@ -839,7 +1904,12 @@ func (p *OrmPlugin) handleChildAssociationsByName(message *generator.Descriptor,
 			action = fmt.Sprintf("%s()", assocHandler)
 		}
 
-		p.P(`if err = db.Model(&ormObj).Association("`, fieldName, `").`, action, `.Error; err != nil {`)
+		if p.gormVersion == GORM_V2 {
+			// v2 association mode returns the error directly
+			p.P(`if err = db.Model(&ormObj).Association("`, fieldName, `").`, action, `; err != nil {`)
+		} else {
+			p.P(`if err = db.Model(&ormObj).Association("`, fieldName, `").`, action, `.Error; err != nil {`)
+		}
 		p.P(`return nil, err`)
 		p.P(`}`)
 		p.P(`ormObj.`, fieldName, ` = nil`)
@@ -886,12 +1956,70 @@ func (p *OrmPlugin) removeChildAssociationsByName(message *generator.Descriptor,
 			ormDesc = "*" + ormDesc
 		}
 		p.P(filterDesc, " = ", ormDesc)
-		p.P(`if err = db.Where(filter`, fieldName, `).Delete(`, strings.Trim(field.Type, "[]*"), `{}).Error; err != nil {`)
+		p.P(`if err = db.Where(filter`, fieldName, `).Delete(&`, strings.Trim(field.Type, "[]*"), `{}).Error; err != nil {`)
 		p.P(`return nil, err`)
 		p.P(`}`)
 	}
 }
 
+// generateVersionCheck increments the version of the locked row if it still is the one of ormObj
+func (p *OrmPlugin) generateVersionCheck(ormable *OrmableType) {
+	versionField := ormable.VersionField
+	column := ormable.Fields[versionField].GetTag().GetColumn()
+	if len(column) == 0 {
+		column = jgorm.ToDBName(versionField)
+	}
+	p.P(`if count > 0 {`)
+	p.P(`res := db.Model(lockedRow).Where("`, column, ` = ?", ormObj.`, versionField, `).UpdateColumn("`, column, `", ormObj.`, versionField, `+1)`)
+	p.P(`if res.Error != nil {`)
+	p.P(`return nil, res.Error`)
+	p.P(`}`)
+	p.P(`if res.RowsAffected == 0 {`)
+	p.P(`return nil, `, p.Import(gerrorsImport), `.ConflictError`)
+	p.P(`}`)
+	p.P(`}`)
+	p.P(`ormObj.`, versionField, `++`)
+}
+
+// generateSetTimestamps overrides the client supplied timestamps of ormObj, the
+// creation time is kept from the row prev if it is set and was found
+func (p *OrmPlugin) generateSetTimestamps(ormable *OrmableType, prev string) {
+	p.UsingGoImports(stdTimeImport)
+	p.P(`now := time.Now()`)
+	createdAt, updatedAt := "now", "now"
+	if strings.HasPrefix(ormable.Fields["UpdatedAt"].Type, "*") {
+		updatedAt = "&now"
+	}
+	if strings.HasPrefix(ormable.Fields["CreatedAt"].Type, "*") {
+		createdAt = "&now"
+	}
+	p.P(`ormObj.UpdatedAt = `, updatedAt)
+	if prev == "" {
+		p.P(`ormObj.CreatedAt = `, createdAt)
+		return
+	}
+	p.P(`ormObj.CreatedAt = `, prev, `.CreatedAt`)
+	if createdAt == "&now" {
+		p.P(`if ormObj.CreatedAt == nil {`)
+	} else {
+		p.P(`if ormObj.CreatedAt.IsZero() {`)
+	}
+	p.P(`ormObj.CreatedAt = `, createdAt)
+	p.P(`}`)
+}
+
+// lockForUpdate returns the chained call locking the selected rows until the
+// end of the transaction, SQLite has no row locks as writes lock the database
+func (p *OrmPlugin) lockForUpdate() string {
+	if p.dbEngine == ENGINE_SQLITE {
+		return ``
+	}
+	if p.gormVersion == GORM_V2 {
+		return fmt.Sprint(`Clauses(`, p.Import(gormClauseImport), `.Locking{Strength: "UPDATE"}).`)
+	}
+	return `Set("gorm:query_option", "FOR UPDATE").`
+}
+
 // guessZeroValue of the input type, so that we can check if a (key) value is set or not
 func (p *OrmPlugin) guessZeroValue(typeName string) string {
 	typeName = strings.ToLower(typeName)
@@ -901,6 +2029,10 @@ func (p *OrmPlugin) guessZeroValue(typeName string) string {
 	if strings.Contains(typeName, "int") {
 		return `0`
 	}
+	if strings.Contains(typeName, "binaryuuid") {
+		// parenthesized, as the literal is compared in if statements
+		return fmt.Sprintf(`(%s.BinaryUUID{})`, p.Import(gtypesImport))
+	}
 	if strings.Contains(typeName, "uuid") {
 		return fmt.Sprintf(`%s.Nil`, p.Import(uuidImport))
 	}
@@ -949,6 +2081,19 @@ func (p *OrmPlugin) listHasPagination(ormable *OrmableType) bool {
 	return false
 }
 
+func (p *OrmPlugin) listHasKeysetPagination(ormable *OrmableType) bool {
+	return p.listHasPagination(ormable) && p.hasPrimaryKey(ormable)
+}
+
+// listHasCount reports whether the List method responds with a PageInfo
+// holding the number of matching rows
+func (p *OrmPlugin) listHasCount(ormable *OrmableType) bool {
+	if read, ok := ormable.Methods[listService]; ok {
+		return p.getPageInfo(read.outType) != "" && !getMethodOptions(read.MethodDescriptorProto).GetSkipCount()
+	}
+	return false
+}
+
 func (p *OrmPlugin) listHasFieldSelection(ormable *OrmableType) bool {
 	if read, ok := ormable.Methods[listService]; ok {
 		if s := p.getFieldSelection(read.inType); s != "" {
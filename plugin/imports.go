@@ -50,14 +50,35 @@ var (
 	ocTraceImport      = "go.opencensus.io/trace"
 	gatewayImport      = "github.com/infobloxopen/atlas-app-toolkit/gateway"
 	pqImport           = "github.com/lib/pq"
+	protoImport        = "github.com/golang/protobuf/proto"
+	jsonpbImport       = "github.com/golang/protobuf/jsonpb"
 	gerrorsImport      = "github.com/suutaku/protoc-gen-gorm/errors"
+	paginationImport   = "github.com/suutaku/protoc-gen-gorm/pagination"
+	fieldmapImport     = "github.com/suutaku/protoc-gen-gorm/fieldmap"
+	schemaImport       = "github.com/suutaku/protoc-gen-gorm/schema"
+	batchImport        = "github.com/suutaku/protoc-gen-gorm/batch"
 	stdFmtImport       = "fmt"
 	stdCtxImport       = "context"
 	stdStringsImport   = "strings"
 	stdTimeImport      = "time"
+	stdSqlImport       = "database/sql"
 	encodingJsonImport = "encoding/json"
+	gormClauseImport   = "gorm.io/gorm/clause"
+	grpcStatusImport   = "google.golang.org/grpc/status"
+	grpcCodesImport    = "google.golang.org/grpc/codes"
 )
 
+// useGormV2Imports switches the plugin level imports to GORM v2 and the
+// matching v2 line of the atlas-app-toolkit
+func useGormV2Imports() {
+	gormImport = "gorm.io/gorm"
+	tkgormImport = "github.com/infobloxopen/atlas-app-toolkit/v2/gorm"
+	authImport = "github.com/infobloxopen/atlas-app-toolkit/v2/auth"
+	resourceImport = "github.com/infobloxopen/atlas-app-toolkit/v2/gorm/resource"
+	queryImport = "github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	gatewayImport = "github.com/infobloxopen/atlas-app-toolkit/v2/gateway"
+}
+
 type pkgImport struct {
 	packagePath string
 	alias       string
@@ -108,9 +129,6 @@ func (p *OrmPlugin) GetFileImports() *fileImports {
 // GenerateImports writes out required imports for the generated files
 func (p *OrmPlugin) GenerateImports(file *generator.FileDescriptor) {
 	imports := p.fileImports[file.GetName()]
-	for _, typeName := range imports.typesToRegister {
-		p.RecordTypeUse(typeName)
-	}
 	githubImports := imports.packages
 	sort.Strings(imports.stdImports)
 	for _, dep := range imports.stdImports {
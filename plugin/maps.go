@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+)
+
+// mapType returns the ORM type, its package and the column type of a map
+// field, all empty if it is not a map or cannot be stored. The maps are JSON
+// documents, or hstore values for a map<string,string> with the hstore type
+// tag on Postgres
+func (p *OrmPlugin) mapType(field *descriptor.FieldDescriptorProto) (string, string, string) {
+	if !p.IsMap(field) {
+		return "", "", ""
+	}
+	m := p.GoMapType(nil, field)
+	// JSON objects have no bool keys
+	if m.KeyField.IsBool() {
+		return "", "", ""
+	}
+	if strings.EqualFold(getFieldOptions(field).GetTag().GetType(), "hstore") && p.dbEngine == ENGINE_POSTGRES {
+		if m.GoType != "map[string]string" {
+			p.Fail("hstore type tag of field", field.GetName(), "is only supported for map<string,string> fields.")
+		}
+		return fmt.Sprintf("%s.Hstore", p.Import(gtypesImport)), gtypesImport, "hstore"
+	}
+	return p.jsonDocumentType()
+}
+
+// jsonDocumentType returns the ORM type, its package and the column type
+// storing a JSON document generated from a field, types.JSONText in a text
+// column if the DB engine has no JSON type
+func (p *OrmPlugin) jsonDocumentType() (string, string, string) {
+	if columnType := p.jsonColumnType(); columnType != "" {
+		return fmt.Sprintf("*%s", p.jsonType()), p.jsonTypeImport(), columnType
+	}
+	return fmt.Sprintf("*%s.JSONText", p.Import(gtypesImport)), gtypesImport, "text"
+}
+
+// generateMapConversion converts a map field to or from its ORM type
+func (p *OrmPlugin) generateMapConversion(field *descriptor.FieldDescriptorProto, ormType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	p.P(`if m.`, fieldName, ` != nil {`)
+	if strings.HasSuffix(ormType, ".Hstore") {
+		if toORM {
+			p.P(`to.`, fieldName, ` = make(`, ormType, `, len(m.`, fieldName, `))`)
+		} else {
+			p.P(`to.`, fieldName, ` = make(map[string]string, len(m.`, fieldName, `))`)
+		}
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.P(`to.`, fieldName, `[k] = v`)
+		p.P(`}`)
+	} else if m := p.GoMapType(nil, field); m.ValueField.IsMessage() {
+		p.generateMessageMapConversion(field, m, ormType, toORM)
+	} else if toORM {
+		p.P(`b, err := `, p.Import(encodingJsonImport), `.Marshal(m.`, fieldName, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(ormType, "*"), `{b}`)
+	} else {
+		p.P(`if err = `, p.Import(encodingJsonImport), `.Unmarshal(m.`, fieldName, `.RawMessage, &to.`, fieldName, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	}
+	p.P(`}`)
+}
+
+// generateMessageMapConversion converts a map field with message values to or
+// from its JSON document, where each value has the JSON mapping of its message.
+// Like in the wire format, a nil value is stored as an empty message
+func (p *OrmPlugin) generateMessageMapConversion(field *descriptor.FieldDescriptorProto, m *generator.GoMapDescriptor, ormType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	keyType := strings.TrimPrefix(m.GoType[:strings.Index(m.GoType, "]")], "map[")
+	valueType := strings.TrimPrefix(m.GoType[strings.Index(m.GoType, "]")+1:], "*")
+	json := p.Import(encodingJsonImport)
+	if toORM {
+		p.P(`values := make(map[`, keyType, `]`, json, `.RawMessage, len(m.`, fieldName, `))`)
+		p.P(`for k, v := range m.`, fieldName, ` {`)
+		p.P(`if v == nil {`)
+		p.P(`v = &`, valueType, `{}`)
+		p.P(`}`)
+		p.P(`s, err := (&`, p.Import(jsonpbImport), `.Marshaler{}).MarshalToString(v)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`values[k] = `, json, `.RawMessage(s)`)
+		p.P(`}`)
+		p.P(`b, err := `, json, `.Marshal(values)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(ormType, "*"), `{b}`)
+		return
+	}
+	p.P(`var values map[`, keyType, `]`, json, `.RawMessage`)
+	p.P(`if err = `, json, `.Unmarshal(m.`, fieldName, `.RawMessage, &values); err != nil {`)
+	p.P(`return to, err`)
+	p.P(`}`)
+	p.P(`to.`, fieldName, ` = make(`, m.GoType, `, len(values))`)
+	p.UsingGoImports("bytes")
+	p.P(`unmarshaler := &`, p.Import(jsonpbImport), `.Unmarshaler{AllowUnknownFields: true}`)
+	p.P(`for k, v := range values {`)
+	p.P(`to.`, fieldName, `[k] = &`, valueType, `{}`)
+	p.P(`if err = unmarshaler.Unmarshal(bytes.NewReader(v), to.`, fieldName, `[k]); err != nil {`)
+	p.P(`return to, err`)
+	p.P(`}`)
+	p.P(`}`)
+}
+
+// generateMapFieldMask patches a map field in DefaultApplyFieldMask, as a
+// whole or, for a path like "Labels.key", the entry of a single key, which is
+// deleted when the patcher doesn't have it
+func (p *OrmPlugin) generateMapFieldMask(field *descriptor.FieldDescriptorProto) {
+	ccName := generator.CamelCase(field.GetName())
+	m := p.GoMapType(nil, field)
+	keyType := strings.TrimPrefix(m.GoType[:strings.Index(m.GoType, "]")], "map[")
+	p.UsingGoImports(stdStringsImport)
+	p.P(`if f == prefix+"`, ccName, `" {`)
+	p.P(`patchee.`, ccName, ` = patcher.`, ccName)
+	p.P(`continue`)
+	p.P(`}`)
+	p.P(`if strings.HasPrefix(f, prefix+"`, ccName, `.") {`)
+	path := fmt.Sprint(`strings.TrimPrefix(f, prefix+"`, ccName, `.")`)
+	switch keyType {
+	case "string":
+		p.P(`key := `, path)
+	case "int32", "int64", "uint32", "uint64":
+		parse, bits := "ParseInt", keyType[len(keyType)-2:]
+		if strings.HasPrefix(keyType, "u") {
+			parse = "ParseUint"
+		}
+		p.UsingGoImports("strconv")
+		p.P(`k, err := strconv.`, parse, `(`, path, `, 10, `, bits, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return nil, err`)
+		p.P(`}`)
+		p.P(`key := `, keyType, `(k)`)
+	}
+	p.P(`if v, ok := patcher.`, ccName, `[key]; ok {`)
+	p.P(`if patchee.`, ccName, ` == nil {`)
+	p.P(`patchee.`, ccName, ` = make(`, m.GoType, `)`)
+	p.P(`}`)
+	p.P(`patchee.`, ccName, `[key] = v`)
+	p.P(`} else {`)
+	p.P(`delete(patchee.`, ccName, `, key)`)
+	p.P(`}`)
+	p.P(`continue`)
+	p.P(`}`)
+}
@@ -0,0 +1,556 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	plugin "github.com/gogo/protobuf/protoc-gen-gogo/plugin"
+	jgorm "github.com/jinzhu/gorm"
+)
+
+/* --------- SQL schema migrations -------- */
+
+const schemaFileSuffix = ".schema.sql"
+
+// sqlTable is the schema of a table as derived from an ormable type, or from
+// a many-to-many association for join tables
+type sqlTable struct {
+	name        string
+	renamedFrom string
+	columns     []*sqlColumn
+	primaryKey  []string
+	indexes     []sqlIndex
+	foreignKeys []sqlForeignKey
+}
+
+type sqlColumn struct {
+	name        string
+	renamedFrom string
+	typ         string
+	notNull     bool
+	unique      bool
+	def         string
+}
+
+// sqlIndex is a (possibly composite) index declared through the index or
+// unique_index tags, fields sharing the same name end up in one index
+type sqlIndex struct {
+	name    string
+	unique  bool
+	columns []string
+}
+
+// sqlForeignKey is a foreign key constraint owned by the table holding the key
+type sqlForeignKey struct {
+	column     string
+	refTable   string
+	refColumn  string
+	constraint string
+}
+
+func (t *sqlTable) column(name string) *sqlColumn {
+	for _, c := range t.columns {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// AddMigrationFiles appends a <file>.schema.sql file next to every generated
+// .pb.gorm.go file when the migrations=sql parameter is set, and the up/down
+// migration scripts when migrations_from is set. It has to be called after
+// CleanFiles, the SQL files must not be treated as Go code
+func (p *OrmPlugin) AddMigrationFiles(response *plugin.CodeGeneratorResponse) {
+	var previous *OrmPlugin
+	if p.migrationsFrom != "" {
+		previous = p.loadPreviousSchema()
+	}
+	// Constraints of a table can come from associations declared in any file,
+	// so the schema is only rendered once all the files have been parsed
+	foreignKeys := p.collectForeignKeys()
+	for _, file := range p.generatedFiles {
+		stem := strings.TrimSuffix(file.GetName(), ".proto")
+		if goFile := findGeneratedFile(response, file.GetName()); goFile != "" {
+			stem = strings.TrimSuffix(goFile, ".pb.gorm.go")
+		}
+		tables := p.schemaTables(file, foreignKeys)
+		if p.sqlMigrations && len(tables) > 0 {
+			response.File = append(response.File, &plugin.CodeGeneratorResponse_File{
+				Name:    proto.String(stem + schemaFileSuffix),
+				Content: proto.String(p.renderSchema(file.GetName(), tables)),
+			})
+		}
+		if previous != nil {
+			response.File = append(response.File, p.diffMigrationFiles(stem, file.GetName(), previous.fileSchemaTables(file.GetName()), tables)...)
+		}
+	}
+}
+
+// findGeneratedFile returns the name of the .pb.gorm.go file generated for
+// the proto file, preferring an exact path match (paths=source_relative)
+func findGeneratedFile(response *plugin.CodeGeneratorResponse, protoName string) string {
+	stem := strings.TrimSuffix(protoName, ".proto")
+	var candidate string
+	for _, file := range response.File {
+		if !strings.HasSuffix(file.GetName(), ".pb.gorm.go") {
+			continue
+		}
+		goStem := strings.TrimSuffix(file.GetName(), ".pb.gorm.go")
+		if goStem == stem {
+			return file.GetName()
+		}
+		if candidate == "" && path.Base(goStem) == path.Base(stem) {
+			candidate = file.GetName()
+		}
+	}
+	return candidate
+}
+
+// schemaTables returns the tables of the ormable messages of the file followed
+// by the join tables of their many-to-many associations
+func (p *OrmPlugin) schemaTables(file *generator.FileDescriptor, foreignKeys map[string][]sqlForeignKey) []*sqlTable {
+	var tables []*sqlTable
+	for _, msg := range file.Messages() {
+		typeName := generator.CamelCaseSlice(msg.TypeName())
+		if !getMessageOptions(msg).GetOrmable() || !p.isOrmable(typeName) {
+			continue
+		}
+		ormable := p.getOrmable(typeName)
+		table := p.buildTable(ormable)
+		table.renamedFrom = getMessageOptions(msg).GetRenamedFrom()
+		table.foreignKeys = foreignKeys[ormable.Name]
+		tables = append(tables, table)
+		for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+			if ormable.Fields[fieldName].GetManyToMany() != nil {
+				if jt := p.buildJoinTable(ormable, ormable.Fields[fieldName]); jt != nil {
+					tables = append(tables, jt)
+				}
+			}
+		}
+	}
+	return tables
+}
+
+func (p *OrmPlugin) buildTable(ormable *OrmableType) *sqlTable {
+	table := &sqlTable{name: ormable.TableName, indexes: p.collectIndexes(ormable)}
+	pkNames := p.primaryKeyNames(ormable)
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		if !isColumnField(field) {
+			continue
+		}
+		tag := field.GetTag()
+		isPK := inStrings(pkNames, fieldName)
+		autoIncrement := tag.GetAutoIncrement() ||
+			(isPK && len(pkNames) == 1 && tag.GetType() == "" && tag.GetDefault() == "" && isIntegerType(field.Type))
+		column := &sqlColumn{
+			name:        columnName(fieldName, field),
+			renamedFrom: field.GetRenamedFrom(),
+			typ:         p.sqlColumnType(field, autoIncrement),
+			notNull:     tag.GetNotNull() || isPK,
+			unique:      tag.GetUnique(),
+			def:         tag.GetDefault(),
+		}
+		if isPK {
+			table.primaryKey = append(table.primaryKey, column.name)
+		}
+		table.columns = append(table.columns, column)
+	}
+	return table
+}
+
+// buildJoinTable builds the join table of a many-to-many association declared
+// on the ormable, along with the constraints to both sides
+func (p *OrmPlugin) buildJoinTable(ormable *OrmableType, field *Field) *sqlTable {
+	mtm := field.GetManyToMany()
+	assoc := p.getOrmable(field.Type)
+	key := ormable.Fields[mtm.GetForeignkey()]
+	assocKey := assoc.Fields[mtm.GetAssociationForeignkey()]
+	if key == nil || assocKey == nil {
+		p.warning("cannot render join table %s for %s, missing key field", mtm.GetJointable(), ormable.Name)
+		return nil
+	}
+	jtKey := jgorm.ToDBName(mtm.GetJointableForeignkey())
+	jtAssocKey := jgorm.ToDBName(mtm.GetAssociationJointableForeignkey())
+	constraint := field.GetTag().GetConstraint()
+	return &sqlTable{
+		name: mtm.GetJointable(),
+		columns: []*sqlColumn{
+			{name: jtKey, typ: p.sqlColumnType(key, false), notNull: true},
+			{name: jtAssocKey, typ: p.sqlColumnType(assocKey, false), notNull: true},
+		},
+		primaryKey: []string{jtKey, jtAssocKey},
+		foreignKeys: []sqlForeignKey{
+			{column: jtKey, refTable: ormable.TableName, refColumn: columnName(mtm.GetForeignkey(), key), constraint: constraint},
+			{column: jtAssocKey, refTable: assoc.TableName, refColumn: columnName(mtm.GetAssociationForeignkey(), assocKey), constraint: constraint},
+		},
+	}
+}
+
+func (p *OrmPlugin) renderSchema(fileName string, tables []*sqlTable) string {
+	var res, indexes, constraints bytes.Buffer
+	fmt.Fprintf(&res, "-- Code generated by protoc-gen-gorm. DO NOT EDIT.\n-- source: %s\n", fileName)
+	for _, table := range tables {
+		res.WriteString("\n" + p.renderCreateTable(table))
+		for _, idx := range table.indexes {
+			indexes.WriteString(p.renderCreateIndex(table.name, idx))
+		}
+		if p.dbEngine != ENGINE_SQLITE {
+			for _, fk := range table.foreignKeys {
+				constraints.WriteString(p.renderAddForeignKey(table.name, fk))
+			}
+		}
+	}
+	for _, part := range []*bytes.Buffer{&indexes, &constraints} {
+		if part.Len() > 0 {
+			res.WriteString("\n")
+			res.Write(part.Bytes())
+		}
+	}
+	return res.String()
+}
+
+func (p *OrmPlugin) renderCreateTable(table *sqlTable) string {
+	var columns []string
+	for _, column := range table.columns {
+		columns = append(columns, p.renderColumn(column))
+	}
+	if len(table.primaryKey) > 0 {
+		columns = append(columns, fmt.Sprintf("PRIMARY KEY (%s)", p.quoteIdents(table.primaryKey)))
+	}
+	if p.dbEngine == ENGINE_SQLITE {
+		// SQLite cannot add constraints to an existing table
+		for _, fk := range table.foreignKeys {
+			columns = append(columns, p.renderForeignKey(table.name, fk))
+		}
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n", p.quoteIdent(table.name), strings.Join(columns, ",\n  "))
+}
+
+func (p *OrmPlugin) renderColumn(column *sqlColumn) string {
+	res := fmt.Sprintf("%s %s", p.quoteIdent(column.name), column.typ)
+	if column.notNull {
+		res += " NOT NULL"
+	}
+	if column.unique {
+		res += " UNIQUE"
+	}
+	if column.def != "" {
+		res += " DEFAULT " + column.def
+	}
+	return res
+}
+
+func (p *OrmPlugin) renderCreateIndex(table string, idx sqlIndex) string {
+	unique := ""
+	if idx.unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);\n", unique, p.quoteIdent(idx.name), p.quoteIdent(table), p.quoteIdents(idx.columns))
+}
+
+func (p *OrmPlugin) renderAddForeignKey(table string, fk sqlForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;\n", p.quoteIdent(table), p.renderForeignKey(table, fk))
+}
+
+func (p *OrmPlugin) renderForeignKey(table string, fk sqlForeignKey) string {
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)%s",
+		p.quoteIdent(fk.name(table)), p.quoteIdent(fk.column),
+		p.quoteIdent(fk.refTable), p.quoteIdent(fk.refColumn), renderReferentialActions(fk.constraint))
+}
+
+func (fk sqlForeignKey) name(table string) string {
+	return fmt.Sprintf("fk_%s_%s", strings.Replace(table, ".", "_", -1), fk.column)
+}
+
+// collectIndexes groups the index and unique_index tags of the ormable by name
+func (p *OrmPlugin) collectIndexes(ormable *OrmableType) []sqlIndex {
+	var indexes []sqlIndex
+	byName := map[string]int{}
+	add := func(name string, unique bool, column string) {
+		if i, ok := byName[name]; ok {
+			indexes[i].columns = append(indexes[i].columns, column)
+			return
+		}
+		byName[name] = len(indexes)
+		indexes = append(indexes, sqlIndex{name: name, unique: unique, columns: []string{column}})
+	}
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		if !isColumnField(field) {
+			continue
+		}
+		tag := field.GetTag()
+		if tag.GetIndex() != "" {
+			add(tag.GetIndex(), false, columnName(fieldName, field))
+		}
+		if tag.GetUniqueIndex() != "" {
+			add(tag.GetUniqueIndex(), true, columnName(fieldName, field))
+		}
+	}
+	return indexes
+}
+
+// collectForeignKeys returns the foreign keys of all the known associations
+// grouped by the name of the ORM type owning the key column
+func (p *OrmPlugin) collectForeignKeys() map[string][]sqlForeignKey {
+	res := map[string][]sqlForeignKey{}
+	var typeNames []string
+	for typeName := range p.ormableTypes {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		ormable := p.ormableTypes[typeName]
+		for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+			field := ormable.Fields[fieldName]
+			if field.GormFieldOptions == nil || field.Association == nil {
+				continue
+			}
+			assoc := p.getOrmable(field.Type)
+			var owner, referenced *OrmableType
+			var foreignKey, assocKey string
+			switch {
+			case field.GetHasMany() != nil:
+				owner, referenced = assoc, ormable
+				foreignKey, assocKey = field.GetHasMany().GetForeignkey(), field.GetHasMany().GetAssociationForeignkey()
+			case field.GetHasOne() != nil:
+				owner, referenced = assoc, ormable
+				foreignKey, assocKey = field.GetHasOne().GetForeignkey(), field.GetHasOne().GetAssociationForeignkey()
+			case field.GetBelongsTo() != nil:
+				owner, referenced = ormable, assoc
+				foreignKey, assocKey = field.GetBelongsTo().GetForeignkey(), field.GetBelongsTo().GetAssociationForeignkey()
+			default:
+				continue
+			}
+			fkField, refField := owner.Fields[foreignKey], referenced.Fields[assocKey]
+			if fkField == nil || refField == nil {
+				continue
+			}
+			res[owner.Name] = append(res[owner.Name], sqlForeignKey{
+				column:     columnName(foreignKey, fkField),
+				refTable:   referenced.TableName,
+				refColumn:  columnName(assocKey, refField),
+				constraint: field.GetTag().GetConstraint(),
+			})
+		}
+	}
+	return res
+}
+
+// primaryKeyNames returns the fields tagged as primary key, or the id field
+func (p *OrmPlugin) primaryKeyNames(ormable *OrmableType) []string {
+	var res []string
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		if ormable.Fields[fieldName].GetTag().GetPrimaryKey() {
+			res = append(res, fieldName)
+		}
+	}
+	if len(res) == 0 {
+		for fieldName := range ormable.Fields {
+			if strings.ToLower(fieldName) == "id" {
+				res = append(res, fieldName)
+			}
+		}
+	}
+	return res
+}
+
+// sqlColumnType maps the Go type of an ORM field to a column type of the
+// selected engine, an explicit type tag always wins
+func (p *OrmPlugin) sqlColumnType(field *Field, autoIncrement bool) string {
+	tag := field.GetTag()
+	if tag.GetType() != "" {
+		return tag.GetType()
+	}
+	goType := strings.TrimPrefix(field.Type, "*")
+	goType = goType[strings.LastIndex(goType, ".")+1:]
+	postgres := p.dbEngine == ENGINE_POSTGRES
+	mysql := p.dbEngine == ENGINE_MYSQL
+	sqlite := p.dbEngine == ENGINE_SQLITE
+	switch goType {
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		if autoIncrement {
+			if postgres {
+				return "serial"
+			} else if mysql {
+				return "integer AUTO_INCREMENT"
+			} else if sqlite {
+				// an integer primary key is the auto incremented rowid
+				return "integer"
+			}
+			return "integer GENERATED BY DEFAULT AS IDENTITY"
+		}
+		return "integer"
+	case "int64", "uint64":
+		typ := "bigint"
+		if mysql && goType == "uint64" {
+			typ = "bigint unsigned"
+		}
+		if autoIncrement {
+			if postgres {
+				return "bigserial"
+			} else if mysql {
+				return typ + " AUTO_INCREMENT"
+			} else if sqlite {
+				return "integer"
+			}
+			return typ + " GENERATED BY DEFAULT AS IDENTITY"
+		}
+		return typ
+	case "float32":
+		return "real"
+	case "float64":
+		if mysql {
+			return "double"
+		}
+		return "double precision"
+	case "string":
+		if tag.GetSize_() != 0 {
+			return fmt.Sprintf("varchar(%d)", tag.GetSize_())
+		}
+		if postgres || sqlite {
+			return "text"
+		}
+		return "varchar(255)"
+	case "[]byte":
+		if postgres {
+			return "bytea"
+		} else if mysql {
+			return "longblob"
+		}
+		return "blob"
+	case "Time", "DeletedAt":
+		if postgres {
+			return "timestamptz"
+		} else if mysql {
+			return "datetime(6)"
+		} else if sqlite {
+			return "datetime"
+		}
+		return "timestamp"
+	case "UUID":
+		if postgres {
+			return "uuid"
+		} else if mysql {
+			return "char(36)"
+		} else if sqlite {
+			return "text"
+		}
+		return "varchar(36)"
+	case "BinaryUUID":
+		return "binary(16)"
+	case "BinaryInet":
+		return "varbinary(16)"
+	case "JSONBoolArray", "JSONFloat32Array", "JSONFloat64Array", "JSONInt32Array", "JSONInt64Array",
+		"JSONUint32Array", "JSONUint64Array", "JSONStringArray", "JSONBytesArray":
+		if sqlite {
+			return "text"
+		}
+		return "json"
+	case "PGInt32Array":
+		return "integer[]"
+	case "PGUint32Array":
+		return "bigint[]"
+	case "PGFloat32Array":
+		return "real[]"
+	case "PGBytesArray":
+		return "bytea[]"
+	case "PGUUIDArray":
+		return "uuid[]"
+	case "PGTimeArray":
+		return "timestamptz[]"
+	case "JSONText":
+		return "text"
+	case "Jsonb":
+		if mysql {
+			return "json"
+		}
+		return "jsonb"
+	}
+	p.warning("no SQL type known for Go type %q, falling back to text, use the type tag to set one", field.Type)
+	return "text"
+}
+
+func (p *OrmPlugin) quoteIdent(name string) string {
+	quote := `"`
+	if p.dbEngine == ENGINE_MYSQL {
+		quote = "`"
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote + part + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+func (p *OrmPlugin) quoteIdents(names []string) string {
+	var quoted []string
+	for _, name := range names {
+		quoted = append(quoted, p.quoteIdent(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// renderReferentialActions turns a GORM constraint tag value such as
+// "OnUpdate:CASCADE,OnDelete:SET NULL" into SQL referential actions
+func renderReferentialActions(constraint string) string {
+	var res string
+	for _, part := range strings.Split(constraint, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.ToLower(kv[0]) {
+		case "ondelete":
+			res += " ON DELETE " + strings.ToUpper(kv[1])
+		case "onupdate":
+			res += " ON UPDATE " + strings.ToUpper(kv[1])
+		}
+	}
+	return res
+}
+
+// isColumnField reports whether the field is stored in a column of the table
+// of its ormable, associations and ignored fields are not
+func isColumnField(field *Field) bool {
+	if field.GetTag().GetIgnore() {
+		return false
+	}
+	if field.GormFieldOptions != nil && field.Association != nil {
+		return false
+	}
+	return field.Type != "interface{}"
+}
+
+func columnName(fieldName string, field *Field) string {
+	if column := field.GetTag().GetColumn(); column != "" {
+		return column
+	}
+	return jgorm.ToDBName(fieldName)
+}
+
+func isIntegerType(goType string) bool {
+	switch strings.TrimPrefix(goType, "*") {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func inStrings(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,416 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	plugin "github.com/gogo/protobuf/protoc-gen-gogo/plugin"
+	"github.com/gogo/protobuf/vanity/command"
+)
+
+/* --------- SQL schema diff migrations -------- */
+
+const (
+	upMigrationSuffix   = ".up.sql"
+	downMigrationSuffix = ".down.sql"
+)
+
+// migrationStep is a single schema change and the statement reverting it
+type migrationStep struct {
+	up   string
+	down string
+}
+
+// loadPreviousSchema runs the plugin over the FileDescriptorSet given with the
+// migrations_from parameter, so that the previous ormable types are collected
+// exactly the way the current ones are
+func (p *OrmPlugin) loadPreviousSchema() *OrmPlugin {
+	data, err := ioutil.ReadFile(p.migrationsFrom)
+	if err != nil {
+		p.Fail("cannot read the migrations_from descriptor set:", err.Error())
+	}
+	set := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		p.Fail("cannot parse the migrations_from descriptor set:", err.Error())
+	}
+	previous := &OrmPlugin{}
+	var toGenerate []string
+	for _, file := range p.generatedFiles {
+		for _, prev := range set.GetFile() {
+			if prev.GetName() == file.GetName() {
+				toGenerate = append(toGenerate, file.GetName())
+			}
+		}
+	}
+	if len(toGenerate) == 0 {
+		return previous
+	}
+	// only the parameters affecting the schema are kept
+	var params []string
+	for _, param := range strings.Split(p.Request.GetParameter(), ",") {
+		if !strings.HasPrefix(param, "migrations") {
+			params = append(params, param)
+		}
+	}
+	response := command.GeneratePlugin(&plugin.CodeGeneratorRequest{
+		FileToGenerate: toGenerate,
+		Parameter:      proto.String(strings.Join(params, ",")),
+		ProtoFile:      set.GetFile(),
+	}, previous, ".pb.gorm.go")
+	if response.Error != nil {
+		p.Fail("cannot process the migrations_from descriptor set:", response.GetError())
+	}
+	return previous
+}
+
+// fileSchemaTables returns the tables of a previously generated proto file
+func (p *OrmPlugin) fileSchemaTables(fileName string) []*sqlTable {
+	for _, file := range p.generatedFiles {
+		if file.GetName() == fileName {
+			return p.schemaTables(file, p.collectForeignKeys())
+		}
+	}
+	return nil
+}
+
+// diffMigrationFiles renders the <file>.up.sql and <file>.down.sql scripts
+// migrating the previous tables of the file to the current ones and back
+func (p *OrmPlugin) diffMigrationFiles(stem, fileName string, previous, current []*sqlTable) []*plugin.CodeGeneratorResponse_File {
+	steps := p.diffSchema(previous, current)
+	if len(steps) == 0 {
+		return nil
+	}
+	var up, down bytes.Buffer
+	header := fmt.Sprintf("-- Code generated by protoc-gen-gorm. DO NOT EDIT.\n-- source: %s\n\n", fileName)
+	up.WriteString(header)
+	down.WriteString(header)
+	for i := range steps {
+		up.WriteString(steps[i].up)
+		down.WriteString(steps[len(steps)-1-i].down)
+	}
+	return []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String(stem + upMigrationSuffix), Content: proto.String(up.String())},
+		{Name: proto.String(stem + downMigrationSuffix), Content: proto.String(down.String())},
+	}
+}
+
+// diffSchema returns the steps migrating the previous tables to the current
+// ones. The down migration is the reverted steps in reverse order
+func (p *OrmPlugin) diffSchema(previous, current []*sqlTable) []migrationStep {
+	var dropFKs, dropIndexes, renames, creates, alters, createIndexes, addFKs, dropTables []migrationStep
+	byName := map[string]*sqlTable{}
+	for _, table := range previous {
+		byName[table.name] = table
+	}
+	matched := map[string]bool{}
+	for _, table := range current {
+		prev := byName[table.name]
+		if prev == nil && table.renamedFrom != "" {
+			if prev = byName[table.renamedFrom]; prev != nil {
+				renames = append(renames, migrationStep{
+					up:   fmt.Sprintf("ALTER TABLE %s RENAME TO %s;\n", p.quoteIdent(prev.name), p.quoteIdent(table.name)),
+					down: fmt.Sprintf("ALTER TABLE %s RENAME TO %s;\n", p.quoteIdent(table.name), p.quoteIdent(prev.name)),
+				})
+			}
+		}
+		if prev == nil || matched[prev.name] {
+			creates = append(creates, migrationStep{
+				up:   p.renderCreateTable(table),
+				down: fmt.Sprintf("DROP TABLE %s;\n", p.quoteIdent(table.name)),
+			})
+			createIndexes = append(createIndexes, p.indexSteps(table.name, table.indexes, true)...)
+			addFKs = append(addFKs, p.foreignKeySteps(table.name, table.foreignKeys, true)...)
+			continue
+		}
+		matched[prev.name] = true
+		if !reflect.DeepEqual(renamedColumns(prev, table, prev.primaryKey), table.primaryKey) {
+			p.warning("primary key of table %s changed, the migration has to be written manually", table.name)
+		}
+		alters = append(alters, p.diffColumns(prev, table)...)
+
+		var removedIndexes, addedIndexes []sqlIndex
+		for _, idx := range prev.indexes {
+			if !containsIndex(table.indexes, idx) {
+				removedIndexes = append(removedIndexes, idx)
+			}
+		}
+		for _, idx := range table.indexes {
+			if !containsIndex(prev.indexes, idx) {
+				addedIndexes = append(addedIndexes, idx)
+			}
+		}
+		dropIndexes = append(dropIndexes, p.indexSteps(prev.name, removedIndexes, false)...)
+		createIndexes = append(createIndexes, p.indexSteps(table.name, addedIndexes, true)...)
+
+		var removedFKs, addedFKs []sqlForeignKey
+		for _, fk := range prev.foreignKeys {
+			if !containsForeignKey(table.foreignKeys, fk) || prev.name != table.name {
+				removedFKs = append(removedFKs, fk)
+			}
+		}
+		for _, fk := range table.foreignKeys {
+			if !containsForeignKey(prev.foreignKeys, fk) || prev.name != table.name {
+				addedFKs = append(addedFKs, fk)
+			}
+		}
+		if p.dbEngine == ENGINE_SQLITE && len(removedFKs)+len(addedFKs) > 0 && prev.name == table.name {
+			p.warning("foreign keys of table %s changed, SQLite cannot alter constraints so the migration has to be written manually", table.name)
+		}
+		dropFKs = append(dropFKs, p.foreignKeySteps(prev.name, removedFKs, false)...)
+		addFKs = append(addFKs, p.foreignKeySteps(table.name, addedFKs, true)...)
+	}
+	var dropped []*sqlTable
+	for _, prev := range previous {
+		if !matched[prev.name] {
+			dropped = append(dropped, prev)
+		}
+	}
+	for _, prev := range dropOrder(dropped) {
+		// the foreign keys go first, so that the tables are recreated before
+		// any of them is added back
+		dropFKs = append(dropFKs, p.foreignKeySteps(prev.name, prev.foreignKeys, false)...)
+		var restore bytes.Buffer
+		restore.WriteString(p.renderCreateTable(prev))
+		for _, step := range p.indexSteps(prev.name, prev.indexes, true) {
+			restore.WriteString(step.up)
+		}
+		dropTables = append(dropTables, migrationStep{
+			up:   fmt.Sprintf("DROP TABLE %s;\n", p.quoteIdent(prev.name)),
+			down: restore.String(),
+		})
+	}
+	var steps []migrationStep
+	for _, phase := range [][]migrationStep{dropFKs, dropIndexes, renames, creates, alters, createIndexes, addFKs, dropTables} {
+		steps = append(steps, phase...)
+	}
+	return steps
+}
+
+// dropOrder sorts the dropped tables so that the tables referencing another
+// one come before it, and the reverted steps recreate the referenced tables
+// first. The tables of a reference cycle keep their previous order
+func dropOrder(tables []*sqlTable) []*sqlTable {
+	var ordered []*sqlTable
+	visited := map[string]bool{}
+	var visit func(table *sqlTable)
+	visit = func(table *sqlTable) {
+		if visited[table.name] {
+			return
+		}
+		visited[table.name] = true
+		for _, other := range tables {
+			for _, fk := range other.foreignKeys {
+				if fk.refTable == table.name && other != table {
+					visit(other)
+				}
+			}
+		}
+		ordered = append(ordered, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return ordered
+}
+
+// diffColumns returns the steps renaming, adding, altering and dropping the
+// columns of a table present in both schemas
+func (p *OrmPlugin) diffColumns(prev, table *sqlTable) []migrationStep {
+	var steps []migrationStep
+	tableName := p.quoteIdent(table.name)
+	matched := map[string]bool{}
+	for _, column := range table.columns {
+		prevColumn := prev.column(column.name)
+		if prevColumn == nil && column.renamedFrom != "" {
+			if prevColumn = prev.column(column.renamedFrom); prevColumn != nil {
+				steps = append(steps, migrationStep{
+					up:   fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n", tableName, p.quoteIdent(prevColumn.name), p.quoteIdent(column.name)),
+					down: fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n", tableName, p.quoteIdent(column.name), p.quoteIdent(prevColumn.name)),
+				})
+			}
+		}
+		if prevColumn == nil || matched[prevColumn.name] {
+			steps = append(steps, migrationStep{
+				up:   fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n", tableName, p.renderColumn(column)),
+				down: fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n", tableName, p.quoteIdent(column.name)),
+			})
+			continue
+		}
+		matched[prevColumn.name] = true
+		if p.dbEngine == ENGINE_SQLITE {
+			// SQLite cannot alter a column, the table has to be recreated
+			if !strings.EqualFold(prevColumn.typ, column.typ) || prevColumn.notNull != column.notNull ||
+				prevColumn.def != column.def || prevColumn.unique != column.unique {
+				p.warning("column %s.%s changed, SQLite cannot alter columns so the migration has to be written manually", table.name, column.name)
+			}
+			continue
+		}
+		alterColumn := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", tableName, p.quoteIdent(column.name))
+		if p.dbEngine == ENGINE_MYSQL {
+			// MySQL redefines the whole column to change its type or nullability
+			if !strings.EqualFold(prevColumn.typ, column.typ) || prevColumn.notNull != column.notNull {
+				steps = append(steps, migrationStep{
+					up:   fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;\n", tableName, p.renderModifiedColumn(column, prevColumn.def)),
+					down: fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;\n", tableName, p.renderModifiedColumn(prevColumn, prevColumn.def)),
+				})
+			}
+		} else if prevType, curType := alterableType(prevColumn.typ), alterableType(column.typ); !strings.EqualFold(prevType, curType) {
+			steps = append(steps, migrationStep{
+				up:   fmt.Sprintf("%s SET DATA TYPE %s;\n", alterColumn, curType),
+				down: fmt.Sprintf("%s SET DATA TYPE %s;\n", alterColumn, prevType),
+			})
+		}
+		if prevColumn.notNull != column.notNull && p.dbEngine != ENGINE_MYSQL {
+			setNotNull := migrationStep{
+				up:   fmt.Sprintf("%s SET NOT NULL;\n", alterColumn),
+				down: fmt.Sprintf("%s DROP NOT NULL;\n", alterColumn),
+			}
+			if !column.notNull {
+				setNotNull.up, setNotNull.down = setNotNull.down, setNotNull.up
+			}
+			steps = append(steps, setNotNull)
+		}
+		if prevColumn.def != column.def {
+			steps = append(steps, migrationStep{
+				up:   renderSetDefault(alterColumn, column.def),
+				down: renderSetDefault(alterColumn, prevColumn.def),
+			})
+		}
+		if prevColumn.unique != column.unique {
+			p.warning("unique constraint of column %s.%s changed, the migration has to be written manually", table.name, column.name)
+		}
+	}
+	for _, prevColumn := range prev.columns {
+		if matched[prevColumn.name] {
+			continue
+		}
+		steps = append(steps, migrationStep{
+			up:   fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n", tableName, p.quoteIdent(prevColumn.name)),
+			down: fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n", tableName, p.renderColumn(prevColumn)),
+		})
+	}
+	return steps
+}
+
+// renamedColumns returns the current names of the previous columns of a
+// table, matched the way diffColumns matches them
+func renamedColumns(prev, table *sqlTable, columns []string) []string {
+	var names []string
+	for _, name := range columns {
+		if table.column(name) == nil {
+			for _, column := range table.columns {
+				if column.renamedFrom == name && prev.column(column.name) == nil {
+					name = column.name
+					break
+				}
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// indexSteps creates the indexes of the table, or drops them when create is false
+func (p *OrmPlugin) indexSteps(table string, indexes []sqlIndex, create bool) []migrationStep {
+	var steps []migrationStep
+	for _, idx := range indexes {
+		step := migrationStep{
+			up:   p.renderCreateIndex(table, idx),
+			down: p.renderDropIndex(table, idx),
+		}
+		if !create {
+			step.up, step.down = step.down, step.up
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// foreignKeySteps adds the constraints to the table, or drops them when add is false
+func (p *OrmPlugin) foreignKeySteps(table string, foreignKeys []sqlForeignKey, add bool) []migrationStep {
+	var steps []migrationStep
+	if p.dbEngine == ENGINE_SQLITE {
+		// the constraints are part of the CREATE TABLE statement
+		return steps
+	}
+	for _, fk := range foreignKeys {
+		step := migrationStep{
+			up:   p.renderAddForeignKey(table, fk),
+			down: p.renderDropForeignKey(table, fk),
+		}
+		if !add {
+			step.up, step.down = step.down, step.up
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func (p *OrmPlugin) renderDropIndex(table string, idx sqlIndex) string {
+	if p.dbEngine == ENGINE_MYSQL {
+		return fmt.Sprintf("DROP INDEX %s ON %s;\n", p.quoteIdent(idx.name), p.quoteIdent(table))
+	}
+	return fmt.Sprintf("DROP INDEX %s;\n", p.quoteIdent(idx.name))
+}
+
+func (p *OrmPlugin) renderDropForeignKey(table string, fk sqlForeignKey) string {
+	if p.dbEngine == ENGINE_MYSQL {
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;\n", p.quoteIdent(table), p.quoteIdent(fk.name(table)))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;\n", p.quoteIdent(table), p.quoteIdent(fk.name(table)))
+}
+
+// renderModifiedColumn renders the column definition of a MySQL MODIFY COLUMN,
+// the unique constraint is left out as it would add a second index and the
+// default is the one in place before the default change step
+func (p *OrmPlugin) renderModifiedColumn(column *sqlColumn, def string) string {
+	modified := *column
+	modified.unique = false
+	modified.def = def
+	return p.renderColumn(&modified)
+}
+
+func renderSetDefault(alterColumn, def string) string {
+	if def == "" {
+		return fmt.Sprintf("%s DROP DEFAULT;\n", alterColumn)
+	}
+	return fmt.Sprintf("%s SET DEFAULT %s;\n", alterColumn, def)
+}
+
+// alterableType strips the auto increment part of a column type, serial types
+// and identity columns cannot be the target of a type change
+func alterableType(typ string) string {
+	typ = strings.TrimSuffix(typ, " GENERATED BY DEFAULT AS IDENTITY")
+	switch strings.ToLower(typ) {
+	case "smallserial":
+		return "smallint"
+	case "serial":
+		return "integer"
+	case "bigserial":
+		return "bigint"
+	}
+	return typ
+}
+
+func containsIndex(indexes []sqlIndex, idx sqlIndex) bool {
+	for _, i := range indexes {
+		if reflect.DeepEqual(i, idx) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsForeignKey(foreignKeys []sqlForeignKey, fk sqlForeignKey) bool {
+	for _, f := range foreignKeys {
+		if f == fk {
+			return true
+		}
+	}
+	return false
+}
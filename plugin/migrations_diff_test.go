@@ -0,0 +1,262 @@
+package plugin
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestDiffSchema diffs a users table against a changed one. The expected
+// scripts quote like Postgres, MySQL ones are compared with backquotes
+func TestDiffSchema(t *testing.T) {
+	all := []int{ENGINE_POSTGRES, ENGINE_MYSQL, ENGINE_SQLITE}
+	users := func(columns ...*sqlColumn) *sqlTable {
+		return &sqlTable{name: "users", columns: append([]*sqlColumn{{name: "id", typ: "integer"}}, columns...), primaryKey: []string{"id"}}
+	}
+	withIndex := users(&sqlColumn{name: "name", typ: "text"})
+	withIndex.indexes = []sqlIndex{{name: "idx_name", columns: []string{"name"}}}
+	renamed := users()
+	renamed.name, renamed.renamedFrom = "accounts", "users"
+	compositeKey := users(&sqlColumn{name: "name", typ: "text"})
+	compositeKey.primaryKey = []string{"id", "name"}
+	renamedKey := users()
+	renamedKey.columns[0].renamedFrom = "uid"
+	oldKey := users()
+	oldKey.columns[0].name, oldKey.primaryKey = "uid", []string{"uid"}
+
+	for _, tc := range []struct {
+		name              string
+		engines           []int
+		previous, current *sqlTable
+		up, down          string
+		warning           string
+	}{
+		{
+			name:     "add column",
+			engines:  all,
+			previous: users(),
+			current:  users(&sqlColumn{name: "name", typ: "text", notNull: true, def: "''"}),
+			up:       `ALTER TABLE "users" ADD COLUMN "name" text NOT NULL DEFAULT '';` + "\n",
+			down:     `ALTER TABLE "users" DROP COLUMN "name";` + "\n",
+		},
+		{
+			name:     "drop column",
+			engines:  all,
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  users(),
+			up:       `ALTER TABLE "users" DROP COLUMN "name";` + "\n",
+			down:     `ALTER TABLE "users" ADD COLUMN "name" text;` + "\n",
+		},
+		{
+			name:     "rename column",
+			engines:  all,
+			previous: users(&sqlColumn{name: "full_name", typ: "text"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", renamedFrom: "full_name"}),
+			up:       `ALTER TABLE "users" RENAME COLUMN "full_name" TO "name";` + "\n",
+			down:     `ALTER TABLE "users" RENAME COLUMN "name" TO "full_name";` + "\n",
+		},
+		{
+			name:     "rename table",
+			engines:  all,
+			previous: users(),
+			current:  renamed,
+			up:       `ALTER TABLE "users" RENAME TO "accounts";` + "\n",
+			down:     `ALTER TABLE "accounts" RENAME TO "users";` + "\n",
+		},
+		{
+			name:     "add index",
+			engines:  []int{ENGINE_POSTGRES, ENGINE_SQLITE},
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  withIndex,
+			up:       `CREATE INDEX "idx_name" ON "users" ("name");` + "\n",
+			down:     `DROP INDEX "idx_name";` + "\n",
+		},
+		{
+			name:     "drop index",
+			engines:  []int{ENGINE_POSTGRES, ENGINE_SQLITE},
+			previous: withIndex,
+			current:  users(&sqlColumn{name: "name", typ: "text"}),
+			up:       `DROP INDEX "idx_name";` + "\n",
+			down:     `CREATE INDEX "idx_name" ON "users" ("name");` + "\n",
+		},
+		{
+			name:     "add index",
+			engines:  []int{ENGINE_MYSQL},
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  withIndex,
+			up:       `CREATE INDEX "idx_name" ON "users" ("name");` + "\n",
+			down:     `DROP INDEX "idx_name" ON "users";` + "\n",
+		},
+		{
+			name:     "drop index",
+			engines:  []int{ENGINE_MYSQL},
+			previous: withIndex,
+			current:  users(&sqlColumn{name: "name", typ: "text"}),
+			up:       `DROP INDEX "idx_name" ON "users";` + "\n",
+			down:     `CREATE INDEX "idx_name" ON "users" ("name");` + "\n",
+		},
+		{
+			name:     "change type",
+			engines:  []int{ENGINE_POSTGRES},
+			previous: users(&sqlColumn{name: "name", typ: "varchar(64)"}),
+			current:  users(&sqlColumn{name: "name", typ: "text"}),
+			up:       `ALTER TABLE "users" ALTER COLUMN "name" SET DATA TYPE text;` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "name" SET DATA TYPE varchar(64);` + "\n",
+		},
+		{
+			name:     "change serial type",
+			engines:  []int{ENGINE_POSTGRES},
+			previous: &sqlTable{name: "users", columns: []*sqlColumn{{name: "id", typ: "serial"}}, primaryKey: []string{"id"}},
+			current:  &sqlTable{name: "users", columns: []*sqlColumn{{name: "id", typ: "bigserial"}}, primaryKey: []string{"id"}},
+			up:       `ALTER TABLE "users" ALTER COLUMN "id" SET DATA TYPE bigint;` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "id" SET DATA TYPE integer;` + "\n",
+		},
+		{
+			name:     "change identity type",
+			engines:  []int{ENGINE_POSTGRES},
+			previous: &sqlTable{name: "users", columns: []*sqlColumn{{name: "id", typ: "integer GENERATED BY DEFAULT AS IDENTITY"}}, primaryKey: []string{"id"}},
+			current:  &sqlTable{name: "users", columns: []*sqlColumn{{name: "id", typ: "bigint GENERATED BY DEFAULT AS IDENTITY"}}, primaryKey: []string{"id"}},
+			up:       `ALTER TABLE "users" ALTER COLUMN "id" SET DATA TYPE bigint;` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "id" SET DATA TYPE integer;` + "\n",
+		},
+		{
+			name:     "set not null",
+			engines:  []int{ENGINE_POSTGRES},
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", notNull: true}),
+			up:       `ALTER TABLE "users" ALTER COLUMN "name" SET NOT NULL;` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "name" DROP NOT NULL;` + "\n",
+		},
+		{
+			name:     "drop not null",
+			engines:  []int{ENGINE_POSTGRES},
+			previous: users(&sqlColumn{name: "name", typ: "text", notNull: true}),
+			current:  users(&sqlColumn{name: "name", typ: "text"}),
+			up:       `ALTER TABLE "users" ALTER COLUMN "name" DROP NOT NULL;` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "name" SET NOT NULL;` + "\n",
+		},
+		{
+			name:     "set default",
+			engines:  []int{ENGINE_POSTGRES, ENGINE_MYSQL},
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", def: "'x'"}),
+			up:       `ALTER TABLE "users" ALTER COLUMN "name" SET DEFAULT 'x';` + "\n",
+			down:     `ALTER TABLE "users" ALTER COLUMN "name" DROP DEFAULT;` + "\n",
+		},
+		{
+			name:     "modify column",
+			engines:  []int{ENGINE_MYSQL},
+			previous: users(&sqlColumn{name: "name", typ: "varchar(64)"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", notNull: true, unique: true}),
+			up:       `ALTER TABLE "users" MODIFY COLUMN "name" text NOT NULL;` + "\n",
+			down:     `ALTER TABLE "users" MODIFY COLUMN "name" varchar(64);` + "\n",
+			warning:  "unique constraint of column users.name changed",
+		},
+		{
+			// the column keeps its default until the default step
+			name:     "modify column and default",
+			engines:  []int{ENGINE_MYSQL},
+			previous: users(&sqlColumn{name: "name", typ: "varchar(64)", def: "'a'"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", def: "'b'"}),
+			up: `ALTER TABLE "users" MODIFY COLUMN "name" text DEFAULT 'a';` + "\n" +
+				`ALTER TABLE "users" ALTER COLUMN "name" SET DEFAULT 'b';` + "\n",
+			down: `ALTER TABLE "users" ALTER COLUMN "name" SET DEFAULT 'a';` + "\n" +
+				`ALTER TABLE "users" MODIFY COLUMN "name" varchar(64) DEFAULT 'a';` + "\n",
+		},
+		{
+			name:     "alter column",
+			engines:  []int{ENGINE_SQLITE},
+			previous: users(&sqlColumn{name: "name", typ: "varchar(64)"}),
+			current:  users(&sqlColumn{name: "name", typ: "text", notNull: true, def: "'x'"}),
+			warning:  "column users.name changed, SQLite cannot alter columns",
+		},
+		{
+			name:     "change primary key",
+			engines:  all,
+			previous: users(&sqlColumn{name: "name", typ: "text"}),
+			current:  compositeKey,
+			warning:  "primary key of table users changed",
+		},
+		{
+			name:     "rename primary key column",
+			engines:  all,
+			previous: oldKey,
+			current:  renamedKey,
+			up:       `ALTER TABLE "users" RENAME COLUMN "uid" TO "id";` + "\n",
+			down:     `ALTER TABLE "users" RENAME COLUMN "id" TO "uid";` + "\n",
+		},
+	} {
+		for _, engine := range tc.engines {
+			var warnings bytes.Buffer
+			log.SetOutput(&warnings)
+			steps := (&OrmPlugin{dbEngine: engine}).diffSchema([]*sqlTable{tc.previous}, []*sqlTable{tc.current})
+			log.SetOutput(os.Stderr)
+			var up, down strings.Builder
+			for i := range steps {
+				up.WriteString(steps[i].up)
+				down.WriteString(steps[len(steps)-1-i].down)
+			}
+			wantUp, wantDown := tc.up, tc.down
+			if engine == ENGINE_MYSQL {
+				wantUp, wantDown = strings.Replace(wantUp, `"`, "`", -1), strings.Replace(wantDown, `"`, "`", -1)
+			}
+			if up.String() != wantUp || down.String() != wantDown {
+				t.Errorf("%s, engine %d: migrated up with\n%s\nand down with\n%s\nwant\n%s\nand\n%s", tc.name, engine, up.String(), down.String(), wantUp, wantDown)
+			}
+			if tc.warning == "" && warnings.Len() > 0 || !strings.Contains(warnings.String(), tc.warning) {
+				t.Errorf("%s, engine %d: warned %q; want %q", tc.name, engine, warnings.String(), tc.warning)
+			}
+		}
+	}
+}
+
+// TestDiffSchemaDropsReferencedTablesLast drops a parent table listed before
+// its child, and checks the foreign key goes first and the child table is
+// dropped before the parent, and recreated after it
+func TestDiffSchemaDropsReferencedTablesLast(t *testing.T) {
+	table := func(name string, fks ...sqlForeignKey) *sqlTable {
+		return &sqlTable{name: name, columns: []*sqlColumn{{name: "id", typ: "integer"}, {name: "parent_id", typ: "integer"}},
+			primaryKey: []string{"id"}, foreignKeys: fks}
+	}
+	previous := []*sqlTable{
+		table("owners"),
+		table("pets", sqlForeignKey{column: "parent_id", refTable: "owners", refColumn: "id"}),
+		table("toys", sqlForeignKey{column: "parent_id", refTable: "pets", refColumn: "id"}),
+		table("kept"),
+	}
+	for _, engine := range []int{ENGINE_POSTGRES, ENGINE_MYSQL, ENGINE_SQLITE} {
+		p := &OrmPlugin{dbEngine: engine}
+		steps := p.diffSchema(previous, previous[3:])
+		var up, down strings.Builder
+		for _, step := range steps {
+			up.WriteString(step.up)
+		}
+		for i := len(steps) - 1; i >= 0; i-- {
+			down.WriteString(steps[i].down)
+		}
+		assertOrder(t, engine, up.String(), "DROP TABLE "+p.quoteIdent("toys"), "DROP TABLE "+p.quoteIdent("pets"),
+			"DROP TABLE "+p.quoteIdent("owners"))
+		assertOrder(t, engine, down.String(), "CREATE TABLE "+p.quoteIdent("owners"), "CREATE TABLE "+p.quoteIdent("pets"),
+			"CREATE TABLE "+p.quoteIdent("toys"))
+		if engine == ENGINE_SQLITE {
+			continue
+		}
+		assertOrder(t, engine, up.String(), p.quoteIdent("fk_pets_parent_id"), "DROP TABLE")
+		assertOrder(t, engine, down.String(), "CREATE TABLE "+p.quoteIdent("toys"), "ADD CONSTRAINT "+p.quoteIdent("fk_pets_parent_id"))
+	}
+}
+
+func assertOrder(t *testing.T, engine int, script string, statements ...string) {
+	t.Helper()
+	last := -1
+	for _, statement := range statements {
+		i := strings.Index(script, statement)
+		if i < 0 || i < last {
+			t.Errorf("engine %d: %q missing or out of order in\n%s", engine, statement, script)
+			return
+		}
+		last = i
+	}
+}
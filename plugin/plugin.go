@@ -29,6 +29,14 @@ const (
 const (
 	ENGINE_UNSET = iota
 	ENGINE_POSTGRES
+	ENGINE_MYSQL
+	ENGINE_SQLITE
+)
+
+// GORM Version Enum
+const (
+	GORM_V1 = iota
+	GORM_V2
 )
 
 var wellKnownTypes = map[string]string{
@@ -40,7 +48,7 @@ var wellKnownTypes = map[string]string{
 	"UInt32Value": "*uint32",
 	"UInt64Value": "*uint64",
 	"BoolValue":   "*bool",
-	//  "BytesValue" : "*[]byte",
+	"BytesValue":  "*[]byte",
 }
 
 var builtinTypes = map[string]struct{}{
@@ -60,10 +68,20 @@ var builtinTypes = map[string]struct{}{
 type OrmableType struct {
 	OriginName string
 	Name       string
-	Package    string
-	File       *generator.FileDescriptor
-	Fields     map[string]*Field
-	Methods    map[string]*autogenMethod
+	TableName  string
+	SoftDelete bool
+	// VersionField is the ORM field name of the optimistic locking version
+	VersionField string
+	Package      string
+	File         *generator.FileDescriptor
+	Fields       map[string]*Field
+	Methods      map[string]*autogenMethod
+	MultiAccount bool
+	// UpsertOn is the unique index DefaultUpsert detects conflicts on
+	UpsertOn string
+	// OwnTransactions makes the multi-statement handlers run in a transaction
+	// of their own when they are not given one
+	OwnTransactions bool
 }
 
 type Field struct {
@@ -88,6 +106,8 @@ func NewOrmableType(oname, pkg string, file *generator.FileDescriptor) *OrmableT
 type OrmPlugin struct {
 	*generator.Generator
 	dbEngine        int
+	mysqlBinaryUUID bool
+	gormVersion     int
 	stringEnums     bool
 	gateway         bool
 	ormableTypes    map[string]*OrmableType
@@ -98,6 +118,9 @@ type OrmPlugin struct {
 	messages        map[string]struct{}
 	ormableServices []autogenService
 	suppressWarn    bool
+	sqlMigrations   bool
+	migrationsFrom  string
+	generatedFiles  []*generator.FileDescriptor
 }
 
 func (p *OrmPlugin) setFile(file string, pkg string) {
@@ -119,9 +142,20 @@ func (p *OrmPlugin) Init(g *generator.Generator) {
 	p.messages = make(map[string]struct{})
 	if strings.EqualFold(g.Param["engine"], "postgres") {
 		p.dbEngine = ENGINE_POSTGRES
+	} else if strings.EqualFold(g.Param["engine"], "mysql") {
+		p.dbEngine = ENGINE_MYSQL
+		p.mysqlBinaryUUID = strings.EqualFold(g.Param["mysql_uuid"], "binary")
+	} else if strings.EqualFold(g.Param["engine"], "sqlite") {
+		p.dbEngine = ENGINE_SQLITE
 	} else {
 		p.dbEngine = ENGINE_UNSET
 	}
+	if strings.EqualFold(g.Param["gorm_version"], "v2") {
+		p.gormVersion = GORM_V2
+		useGormV2Imports()
+	} else {
+		p.gormVersion = GORM_V1
+	}
 	if strings.EqualFold(g.Param["enums"], "string") {
 		p.stringEnums = true
 	}
@@ -131,6 +165,10 @@ func (p *OrmPlugin) Init(g *generator.Generator) {
 	if _, ok := g.Param["quiet"]; ok {
 		p.suppressWarn = true
 	}
+	if strings.EqualFold(g.Param["migrations"], "sql") {
+		p.sqlMigrations = true
+	}
+	p.migrationsFrom = g.Param["migrations_from"]
 }
 
 // Generate produces the code generated by the plugin for this file,
@@ -179,12 +217,14 @@ func (p *OrmPlugin) Generate(file *generator.FileDescriptor) {
 			}
 		}
 	}
-	for _, fileProto := range p.AllFiles().GetFile() {
-		p.setFile(fileProto.GetName(), fileProto.GetPackage())
-		p.parseServices(file)
-	}
 	// Return to the file at hand and then generate anything needed
 	p.setFile(file.GetName(), file.GetPackage())
+	p.parseServices(file)
+	// The generator writes the imports of the types it saw used before
+	// GenerateImports is called
+	for _, typeName := range p.GetFileImports().typesToRegister {
+		p.RecordTypeUse(typeName)
+	}
 	empty := true
 	for _, msg := range file.Messages() {
 		typeName := p.getMsgName(msg)
@@ -194,10 +234,17 @@ func (p *OrmPlugin) Generate(file *generator.FileDescriptor) {
 			p.generateTableNameFunction(msg)
 			p.generateConvertFunctions(msg)
 			p.generateHookInterfaces(msg)
+			p.generateSchema(msg)
+			p.generateQueryBuilder(msg)
 		}
 	}
 	p.generateDefaultHandlers(file)
 	p.generateDefaultServer(file)
+	for _, name := range p.Request.GetFileToGenerate() {
+		if name == file.GetName() {
+			p.generatedFiles = append(p.generatedFiles, file)
+		}
+	}
 	// no ormable objects, and no imports (means no services generated)
 	if empty && len(p.GetFileImports().packages) == 0 {
 		p.EmptyFiles = append(p.EmptyFiles, file.GetName())
@@ -208,6 +255,7 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 	typeName := p.getMsgName(msg)
 	ormable := p.getOrmable(typeName)
 	ormable.Name = fmt.Sprintf("%sORM", typeName)
+	ormable.TableName = getTableName(msg)
 	for _, field := range msg.GetField() {
 		fieldOpts := getFieldOptions(field)
 		if fieldOpts == nil {
@@ -220,24 +268,26 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 		fieldName := generator.CamelCase(field.GetName())
 		fieldType, _ := p.GoType(msg, field)
 		var typePackage string
-		if p.dbEngine == ENGINE_POSTGRES && p.IsAbleToMakePQArray(fieldType) {
-			switch fieldType {
-			case "[]bool":
-				fieldType = fmt.Sprintf("%s.BoolArray", p.Import(pqImport))
-				fieldOpts.Tag = tagWithType(tag, "bool[]")
-			case "[]float64":
-				fieldType = fmt.Sprintf("%s.Float64Array", p.Import(pqImport))
-				fieldOpts.Tag = tagWithType(tag, "float[]")
-			case "[]int64":
-				fieldType = fmt.Sprintf("%s.Int64Array", p.Import(pqImport))
-				fieldOpts.Tag = tagWithType(tag, "integer[]")
-			case "[]string":
-				fieldType = fmt.Sprintf("%s.StringArray", p.Import(pqImport))
-				fieldOpts.Tag = tagWithType(tag, "text[]")
-			default:
-				continue
+		if ormType, ormPackage, columnType := p.serializedType(field, fieldType); ormType != "" {
+			// Register type used, in case it's an imported type from another package
+			p.GetFileImports().typesToRegister = append(p.GetFileImports().typesToRegister, field.GetTypeName())
+			fieldType, typePackage = ormType, ormPackage
+			if columnType != "" {
+				fieldOpts.Tag = tagWithType(tag, columnType)
 			}
+		} else if ormType, ormPackage, columnType := p.mapType(field); ormType != "" {
+			if value := p.GoMapType(nil, field).ValueField; value.IsMessage() {
+				p.GetFileImports().typesToRegister = append(p.GetFileImports().typesToRegister, value.GetTypeName())
+			}
+			fieldType, typePackage = ormType, ormPackage
+			fieldOpts.Tag = tagWithType(tag, columnType)
+		} else if arrayType, columnType := p.arrayType(field, fieldType); arrayType != "" {
+			fieldType = arrayType
+			fieldOpts.Tag = tagWithType(tag, columnType)
 		} else if (*(field.Type) != typeMessage || !p.isOrmable(fieldType)) && field.IsRepeated() {
+			if p.IsMap(field) {
+				p.warning("map field %s of %s is not stored, JSON objects have no bool keys", field.GetName(), typeName)
+			}
 			// Not implemented yet
 			continue
 		} else if *(field.Type) == typeEnum {
@@ -255,26 +305,37 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 				fieldType = v
 				typePackage = wktImport
 			} else if rawType == protoTypeUUID {
-				fieldType = fmt.Sprintf("%s.UUID", p.Import(uuidImport))
-				typePackage = uuidImport
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldOpts.Tag = tagWithType(tag, "uuid")
+				fieldType, typePackage = p.uuidType()
+				if columnType := p.uuidColumnType(); columnType != "" {
+					fieldOpts.Tag = tagWithType(tag, columnType)
 				}
 			} else if rawType == protoTypeUUIDValue {
-				fieldType = fmt.Sprintf("*%s.UUID", p.Import(uuidImport))
-				typePackage = uuidImport
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldOpts.Tag = tagWithType(tag, "uuid")
+				fieldType, typePackage = p.uuidType()
+				fieldType = "*" + fieldType
+				if columnType := p.uuidColumnType(); columnType != "" {
+					fieldOpts.Tag = tagWithType(tag, columnType)
 				}
 			} else if rawType == protoTypeTimestamp {
 				p.UsingGoImports(stdTimeImport)
 				typePackage = stdTimeImport
 				fieldType = fmt.Sprintf("*%s.Time", typePackage)
+			} else if wellKnownMessage(field) == protoTypeDuration {
+				fieldType, typePackage = p.durationType(field)
+				if strings.HasSuffix(fieldType, ".Interval") {
+					fieldOpts.Tag = tagWithType(tag, "interval")
+				} else {
+					fieldOpts.Tag = tagWithType(tag, "bigint")
+				}
+			} else if wellKnownMessage(field) == protoTypeFieldMask {
+				p.GetFileImports().typesToRegister = append(p.GetFileImports().typesToRegister, field.GetTypeName())
+				var columnType string
+				fieldType, typePackage, columnType = p.fieldMaskType()
+				fieldOpts.Tag = tagWithType(tag, columnType)
 			} else if rawType == protoTypeJSON {
-				if p.dbEngine == ENGINE_POSTGRES {
-					fieldType = fmt.Sprintf("*%s.Jsonb", p.Import(gormpqImport))
-					typePackage = gormpqImport
-					fieldOpts.Tag = tagWithType(tag, "jsonb")
+				if columnType := p.jsonColumnType(); columnType != "" {
+					fieldType = fmt.Sprintf("*%s", p.jsonType())
+					typePackage = p.jsonTypeImport()
+					fieldOpts.Tag = tagWithType(tag, columnType)
 				} else {
 					// Potential TODO: add types we want to use in other/default DB engine
 					continue
@@ -311,6 +372,11 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 				typePackage = gtypesImport
 				if p.dbEngine == ENGINE_POSTGRES {
 					fieldOpts.Tag = tagWithType(tag, "inet")
+				} else if p.dbEngine == ENGINE_MYSQL {
+					fieldType = fmt.Sprintf("*%s.BinaryInet", p.Import(gtypesImport))
+					fieldOpts.Tag = tagWithType(tag, "varbinary(16)")
+				} else if p.dbEngine == ENGINE_SQLITE {
+					fieldOpts.Tag = tagWithType(tag, "text")
 				} else {
 					fieldOpts.Tag = tagWithType(tag, "varchar(48)")
 				}
@@ -330,7 +396,9 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 		}
 		ormable.Fields[fieldName] = f
 	}
+	ormable.UpsertOn = getMessageOptions(msg).GetUpsertOn()
 	if getMessageOptions(msg).GetMultiAccount() {
+		ormable.MultiAccount = true
 		if accID, ok := ormable.Fields["AccountID"]; !ok {
 			ormable.Fields["AccountID"] = &Field{Type: "string"}
 		} else {
@@ -339,6 +407,32 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 			}
 		}
 	}
+	if versionField := getMessageOptions(msg).GetVersionField(); versionField != "" {
+		fieldName := generator.CamelCase(versionField)
+		if field, ok := ormable.Fields[fieldName]; !ok || !isIntegerType(field.Type) {
+			p.Fail("version_field", versionField, "of", ormable.Name, "is not an integer field.")
+		}
+		ormable.VersionField = fieldName
+	}
+	if getMessageOptions(msg).GetTimestamps() {
+		for _, fieldName := range []string{"CreatedAt", "UpdatedAt"} {
+			if field, ok := ormable.Fields[fieldName]; !ok {
+				p.UsingGoImports(stdTimeImport)
+				ormable.Fields[fieldName] = &Field{Type: "time.Time", Package: stdTimeImport}
+			} else if strings.TrimPrefix(field.Type, "*") != "time.Time" {
+				p.Fail("Cannot include", fieldName, "field into", ormable.Name, "as it already exists there with a type other than google.protobuf.Timestamp.")
+			}
+		}
+	}
+	if getMessageOptions(msg).GetSoftDelete() {
+		ormable.SoftDelete = true
+		deletedAt := p.deletedAtField(ormable)
+		if field, ok := ormable.Fields["DeletedAt"]; !ok {
+			ormable.Fields["DeletedAt"] = deletedAt
+		} else if field.Type != deletedAt.Type {
+			p.Fail("Cannot include DeletedAt field into", ormable.Name, "as it already exists there with a different type.")
+		}
+	}
 	for _, field := range getMessageOptions(msg).GetInclude() {
 		fieldName := generator.CamelCase(field.GetName())
 		if _, ok := ormable.Fields[fieldName]; !ok {
@@ -349,6 +443,88 @@ func (p *OrmPlugin) parseBasicFields(msg *generator.Descriptor) {
 	}
 }
 
+// deletedAtField returns the column marking the rows of a soft_delete ormable
+// as deleted, GORM excludes the rows where it is set from the queries
+func (p *OrmPlugin) deletedAtField(ormable *OrmableType) *Field {
+	tag := &gorm.GormTag{Index: fmt.Sprintf("idx_%s_deleted_at", strings.Replace(ormable.TableName, ".", "_", -1))}
+	if p.gormVersion == GORM_V2 {
+		return &Field{Type: fmt.Sprintf("%s.DeletedAt", p.Import(gormImport)), Package: gormImport, GormFieldOptions: &gorm.GormFieldOptions{Tag: tag}}
+	}
+	p.UsingGoImports(stdTimeImport)
+	return &Field{Type: "*time.Time", Package: stdTimeImport, GormFieldOptions: &gorm.GormFieldOptions{Tag: tag}}
+}
+
+// uuidType returns the ORM type of UUID fields and its package
+func (p *OrmPlugin) uuidType() (string, string) {
+	if p.dbEngine == ENGINE_MYSQL && p.mysqlBinaryUUID {
+		return fmt.Sprintf("%s.BinaryUUID", p.Import(gtypesImport)), gtypesImport
+	}
+	return fmt.Sprintf("%s.UUID", p.Import(uuidImport)), uuidImport
+}
+
+func (p *OrmPlugin) uuidFromString() string {
+	if p.dbEngine == ENGINE_MYSQL && p.mysqlBinaryUUID {
+		return fmt.Sprintf("%s.BinaryUUIDFromString", p.Import(gtypesImport))
+	}
+	return fmt.Sprintf("%s.FromString", p.Import(uuidImport))
+}
+
+func (p *OrmPlugin) uuidNil() string {
+	if p.dbEngine == ENGINE_MYSQL && p.mysqlBinaryUUID {
+		return fmt.Sprintf("%s.BinaryUUID{}", p.Import(gtypesImport))
+	}
+	return fmt.Sprintf("%s.Nil", p.Import(uuidImport))
+}
+
+// uuidColumnType returns the column type of UUID fields, empty when the DB
+// engine has no specific one
+func (p *OrmPlugin) uuidColumnType() string {
+	switch p.dbEngine {
+	case ENGINE_POSTGRES:
+		return "uuid"
+	case ENGINE_MYSQL:
+		if p.mysqlBinaryUUID {
+			return "binary(16)"
+		}
+		return "char(36)"
+	case ENGINE_SQLITE:
+		return "text"
+	}
+	return ""
+}
+
+// jsonColumnType returns the column type of JSONValue fields, empty when the
+// DB engine does not support them
+func (p *OrmPlugin) jsonColumnType() string {
+	switch p.dbEngine {
+	case ENGINE_POSTGRES:
+		return "jsonb"
+	case ENGINE_MYSQL:
+		return "json"
+	case ENGINE_SQLITE:
+		return "text"
+	}
+	return ""
+}
+
+// jsonType returns the ORM type of JSONValue fields, SQLite has no JSON column
+// type so the document is validated by types.JSONText instead
+func (p *OrmPlugin) jsonType() string {
+	if p.dbEngine == ENGINE_SQLITE {
+		return fmt.Sprintf("%s.JSONText", p.Import(p.jsonTypeImport()))
+	}
+	return fmt.Sprintf("%s.Jsonb", p.Import(p.jsonTypeImport()))
+}
+
+// jsonTypeImport returns the package of the JSON type, the postgres dialect of
+// jinzhu/gorm is only used for GORM v1 code on Postgres
+func (p *OrmPlugin) jsonTypeImport() string {
+	if p.gormVersion == GORM_V1 && p.dbEngine == ENGINE_POSTGRES {
+		return gormpqImport
+	}
+	return gtypesImport
+}
+
 func tagWithType(tag *gorm.GormTag, typename string) *gorm.GormTag {
 	if tag == nil {
 		tag = &gorm.GormTag{}
@@ -380,9 +556,9 @@ func (p *OrmPlugin) addIncludedField(ormable *OrmableType, field *gorm.ExtraFiel
 		} else if rawType == "UUID" {
 			rawType = fmt.Sprintf("%s.UUID", p.Import(uuidImport))
 			typePackage = uuidImport
-		} else if field.GetType() == "Jsonb" && p.dbEngine == ENGINE_POSTGRES {
-			rawType = fmt.Sprintf("%s.Jsonb", p.Import(gormpqImport))
-			typePackage = gormpqImport
+		} else if field.GetType() == "Jsonb" && p.jsonColumnType() != "" {
+			rawType = p.jsonType()
+			typePackage = p.jsonTypeImport()
 		} else if rawType == "Inet" {
 			rawType = fmt.Sprintf("%s.Inet", p.Import(gtypesImport))
 			typePackage = gtypesImport
@@ -426,16 +602,19 @@ func (p *OrmPlugin) getSortedFieldNames(fields map[string]*Field) []string {
 
 func (p *OrmPlugin) generateOrmable(message *generator.Descriptor) {
 	ormable := p.getOrmable(p.TypeName(message))
+	compositeKey := len(p.primaryKeyNames(ormable)) > 1
 	p.P(`type `, ormable.Name, ` struct {`)
 	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
 		field := ormable.Fields[fieldName]
-		p.P(fieldName, ` `, field.Type, p.renderGormTag(field))
+		p.P(fieldName, ` `, field.Type, p.renderGormTag(field, compositeKey))
 
 	}
 	p.P(`}`)
 }
 
-func (p *OrmPlugin) renderGormTag(field *Field) string {
+// renderGormTag renders the tags of an ORM field, GORM must not generate the
+// values of the integer columns of a composite primary key
+func (p *OrmPlugin) renderGormTag(field *Field, compositeKey bool) string {
 	var gormRes, atlasRes string
 	tag := field.GetTag()
 	if tag == nil {
@@ -455,7 +634,7 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 		gormRes += fmt.Sprintf("precision:%d;", tag.GetPrecision())
 	}
 	if tag.GetPrimaryKey() {
-		gormRes += "primary_key;"
+		gormRes += p.gormTagKey("primary_key", "primaryKey") + ";"
 	}
 	if tag.GetUnique() {
 		gormRes += "unique;"
@@ -467,7 +646,9 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 		gormRes += "not null;"
 	}
 	if tag.GetAutoIncrement() {
-		gormRes += "auto_increment;"
+		gormRes += p.gormTagKey("auto_increment", "autoIncrement") + ";"
+	} else if compositeKey && tag.GetPrimaryKey() && isIntegerType(field.Type) {
+		gormRes += p.gormTagKey("auto_increment", "autoIncrement") + ":false;"
 	}
 	if tag.Index != "" {
 		if tag.GetIndex() == "" {
@@ -478,16 +659,16 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 	}
 	if tag.UniqueIndex != "" {
 		if tag.GetUniqueIndex() == "" {
-			gormRes += "unique_index;"
+			gormRes += p.gormTagKey("unique_index", "uniqueIndex") + ";"
 		} else {
-			gormRes += fmt.Sprintf("unique_index:%s;", tag.GetUniqueIndex())
+			gormRes += fmt.Sprintf("%s:%s;", p.gormTagKey("unique_index", "uniqueIndex"), tag.GetUniqueIndex())
 		}
 	}
 	if tag.GetEmbedded() {
 		gormRes += "embedded;"
 	}
 	if tag.EmbeddedPrefix != "" {
-		gormRes += fmt.Sprintf("embedded_prefix:%s;", tag.GetEmbeddedPrefix())
+		gormRes += fmt.Sprintf("%s:%s;", p.gormTagKey("embedded_prefix", "embeddedPrefix"), tag.GetEmbeddedPrefix())
 	}
 	if tag.GetIgnore() {
 		gormRes += "-;"
@@ -550,6 +731,39 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 		preload = tag.Preload
 	}
 
+	if p.gormVersion == GORM_V2 {
+		gormRes += p.renderGormV2AssociationTag(foreignKey, associationForeignKey, joinTable, joinTableForeignKey, associationJoinTableForeignKey, tag.GetConstraint())
+	} else {
+		gormRes += p.renderGormV1AssociationTag(foreignKey, associationForeignKey, joinTable, joinTableForeignKey, associationJoinTableForeignKey,
+			associationAutoupdate, associationAutocreate, associationSaveReference, preload, clear, replace, append)
+	}
+
+	var gormTag, atlasTag string
+	if gormRes != "" {
+		gormTag = fmt.Sprintf("gorm:\"%s\"", strings.TrimRight(gormRes, ";"))
+	}
+	if atlasRes != "" {
+		atlasTag = fmt.Sprintf("atlas:\"%s\"", strings.TrimRight(atlasRes, ";"))
+	}
+	finalTag := strings.TrimSpace(strings.Join([]string{gormTag, atlasTag}, " "))
+	if finalTag == "" {
+		return ""
+	} else {
+		return fmt.Sprintf("`%s`", finalTag)
+	}
+}
+
+// gormTagKey picks the spelling of a gorm tag key for the target GORM version
+func (p *OrmPlugin) gormTagKey(v1, v2 string) string {
+	if p.gormVersion == GORM_V2 {
+		return v2
+	}
+	return v1
+}
+
+func (p *OrmPlugin) renderGormV1AssociationTag(foreignKey, associationForeignKey, joinTable, joinTableForeignKey, associationJoinTableForeignKey string,
+	associationAutoupdate, associationAutocreate, associationSaveReference, preload, clear, replace, append bool) string {
+	var gormRes string
 	gormRes += fmt.Sprintf("foreignkey:%s;", foreignKey)
 
 	gormRes += fmt.Sprintf("association_foreignkey:%s;", associationForeignKey)
@@ -572,30 +786,35 @@ func (p *OrmPlugin) renderGormTag(field *Field) string {
 
 	gormRes += fmt.Sprintf("preload:%s;", strconv.FormatBool(preload))
 
-	// if clear != nil {
-	// 	gormRes += fmt.Sprintf("clear:%s;", strconv.FormatBool(*clear))
-	// } else if replace != nil {
-	// 	gormRes += fmt.Sprintf("replace:%s;", strconv.FormatBool(*replace))
-	// } else if append != nil {
-	// 	gormRes += fmt.Sprintf("append:%s;", strconv.FormatBool(*append))
-	// }
 	gormRes += fmt.Sprintf("clear:%s;", strconv.FormatBool(clear))
 	gormRes += fmt.Sprintf("replace:%s;", strconv.FormatBool(replace))
 	gormRes += fmt.Sprintf("append:%s;", strconv.FormatBool(append))
+	return gormRes
+}
 
-	var gormTag, atlasTag string
-	if gormRes != "" {
-		gormTag = fmt.Sprintf("gorm:\"%s\"", strings.TrimRight(gormRes, ";"))
+// renderGormV2AssociationTag renders association settings with GORM v2 keys,
+// the autoupdate/autocreate/preload flags have no tag equivalent in v2
+func (p *OrmPlugin) renderGormV2AssociationTag(foreignKey, references, joinTable, joinForeignKey, joinReferences, constraint string) string {
+	var gormRes string
+	if foreignKey != "" {
+		gormRes += fmt.Sprintf("foreignKey:%s;", foreignKey)
 	}
-	if atlasRes != "" {
-		atlasTag = fmt.Sprintf("atlas:\"%s\"", strings.TrimRight(atlasRes, ";"))
+	if references != "" {
+		gormRes += fmt.Sprintf("references:%s;", references)
 	}
-	finalTag := strings.TrimSpace(strings.Join([]string{gormTag, atlasTag}, " "))
-	if finalTag == "" {
-		return ""
-	} else {
-		return fmt.Sprintf("`%s`", finalTag)
+	if joinTable != "" {
+		gormRes += fmt.Sprintf("many2many:%s;", joinTable)
+	}
+	if joinForeignKey != "" {
+		gormRes += fmt.Sprintf("joinForeignKey:%s;", joinForeignKey)
 	}
+	if joinReferences != "" {
+		gormRes += fmt.Sprintf("joinReferences:%s;", joinReferences)
+	}
+	if constraint != "" {
+		gormRes += fmt.Sprintf("constraint:%s;", constraint)
+	}
+	return gormRes
 }
 
 // generateTableNameFunction the function to set the gorm table name
@@ -606,12 +825,17 @@ func (p *OrmPlugin) generateTableNameFunction(message *generator.Descriptor) {
 	p.P(`// TableName overrides the default tablename generated by GORM`)
 	p.P(`func (`, typeName, `ORM) TableName() string {`)
 
-	tableName := inflection.Plural(jgorm.ToDBName(message.GetName()))
+	p.P(`return "`, getTableName(message), `"`)
+	p.P(`}`)
+}
+
+// getTableName returns the table option of the message or the plural snake
+// case of its name, following the GORM naming convention
+func getTableName(message *generator.Descriptor) string {
 	if opts := getMessageOptions(message); opts != nil && len(opts.Table) > 0 {
-		tableName = opts.GetTable()
+		return opts.GetTable()
 	}
-	p.P(`return "`, tableName, `"`)
-	p.P(`}`)
+	return inflection.Plural(jgorm.ToDBName(message.GetName()))
 }
 
 // generateMapFunctions creates the converter functions
@@ -681,24 +905,80 @@ func (p *OrmPlugin) generateConvertFunctions(message *generator.Descriptor) {
 	p.P(`}`)
 }
 
+// generateMessageArrayConversion converts the elements of a repeated UUID or
+// Timestamp field one by one, to or from the elements of the Postgres array
+func (p *OrmPlugin) generateMessageArrayConversion(field *descriptor.FieldDescriptorProto, fieldType, arrayType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	isUUID := strings.HasSuffix(fieldType, "."+protoTypeUUID)
+	p.P(`if m.`, fieldName, ` != nil {`)
+	// the proto message types may not be imported, an empty repeated field
+	// is nil anyway
+	if toORM {
+		p.P(`to.`, fieldName, ` = make(`, arrayType, `, 0, len(m.`, fieldName, `))`)
+	}
+	p.P(`for _, v := range m.`, fieldName, ` {`)
+	switch {
+	case toORM && isUUID:
+		p.P(`u, err := `, p.Import(gtypesImport), `.BinaryUUIDFromString(v.GetValue())`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, u)`)
+	case isUUID:
+		p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, &`, p.Import(gtypesImport), `.UUID{Value: v.String()})`)
+	case toORM:
+		p.P(`t, err := `, p.Import(ptypesImport), `.Timestamp(v)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, t)`)
+	default:
+		p.P(`t, err := `, p.Import(ptypesImport), `.TimestampProto(v)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, t)`)
+	}
+	p.P(`}`)
+	p.P(`}`)
+}
+
 // Output code that will convert a field to/from orm.
 func (p *OrmPlugin) generateFieldConversion(message *generator.Descriptor, field *descriptor.FieldDescriptorProto, toORM bool, ofield *Field) error {
 	fieldName := generator.CamelCase(field.GetName())
 	fieldType, _ := p.GoType(message, field)
-	if field.IsRepeated() { // Repeated Object ----------------------------------
-		// Some repeated fields can be handled by github.com/lib/pq
-		if p.dbEngine == ENGINE_POSTGRES && p.IsAbleToMakePQArray(fieldType) {
+	if ormType, _, _ := p.serializedType(field, fieldType); ormType != "" {
+		p.generateSerializedConversion(field, fieldType, ormType, toORM)
+	} else if field.IsRepeated() { // Repeated Object ----------------------------------
+		// Some repeated fields can be stored as arrays, natively or as JSON
+		if ormType, _, _ := p.mapType(field); ormType != "" {
+			p.generateMapConversion(field, ormType, toORM)
+		} else if arrayType, _ := p.arrayType(field, fieldType); arrayType != "" && field.IsEnum() {
+			elemType := strings.TrimPrefix(fieldType, "[]")
 			p.P(`if m.`, fieldName, ` != nil {`)
-			switch fieldType {
-			case "[]bool":
-				p.P(`to.`, fieldName, ` = make(`, p.Import(pqImport), `.BoolArray, len(m.`, fieldName, `))`)
-			case "[]float64":
-				p.P(`to.`, fieldName, ` = make(`, p.Import(pqImport), `.Float64Array, len(m.`, fieldName, `))`)
-			case "[]int64":
-				p.P(`to.`, fieldName, ` = make(`, p.Import(pqImport), `.Int64Array, len(m.`, fieldName, `))`)
-			case "[]string":
-				p.P(`to.`, fieldName, ` = make(`, p.Import(pqImport), `.StringArray, len(m.`, fieldName, `))`)
+			if toORM {
+				p.P(`to.`, fieldName, ` = make(`, arrayType, `, 0, len(m.`, fieldName, `))`)
+			} else {
+				p.P(`to.`, fieldName, ` = make(`, fieldType, `, 0, len(m.`, fieldName, `))`)
 			}
+			p.P(`for _, v := range m.`, fieldName, ` {`)
+			switch {
+			case toORM && p.stringEnums:
+				p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, `, elemType, `_name[int32(v)])`)
+			case toORM:
+				p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, int32(v))`)
+			case p.stringEnums:
+				p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, `, elemType, `(`, elemType, `_value[v]))`)
+			default:
+				p.P(`to.`, fieldName, ` = append(to.`, fieldName, `, `, elemType, `(v))`)
+			}
+			p.P(`}`)
+			p.P(`}`)
+		} else if arrayType != "" && field.IsMessage() {
+			p.generateMessageArrayConversion(field, fieldType, arrayType, toORM)
+		} else if arrayType != "" {
+			p.P(`if m.`, fieldName, ` != nil {`)
+			p.P(`to.`, fieldName, ` = make(`, arrayType, `, len(m.`, fieldName, `))`)
 			p.P(`copy(to.`, fieldName, `, m.`, fieldName, `)`)
 			p.P(`}`)
 		} else if p.isOrmable(fieldType) { // Repeated ORMable type
@@ -756,7 +1036,7 @@ func (p *OrmPlugin) generateFieldConversion(message *generator.Descriptor, field
 		} else if coreType == protoTypeUUIDValue { // Singular UUIDValue type ----
 			if toORM {
 				p.P(`if m.`, fieldName, ` != nil {`)
-				p.P(`tempUUID, uErr := `, p.Import(uuidImport), `.FromString(m.`, fieldName, `.Value)`)
+				p.P(`tempUUID, uErr := `, p.uuidFromString(), `(m.`, fieldName, `.Value)`)
 				p.P(`if uErr != nil {`)
 				p.P(`return to, uErr`)
 				p.P(`}`)
@@ -770,16 +1050,20 @@ func (p *OrmPlugin) generateFieldConversion(message *generator.Descriptor, field
 		} else if coreType == protoTypeUUID { // Singular UUID type --------------
 			if toORM {
 				p.P(`if m.`, fieldName, ` != nil {`)
-				p.P(`to.`, fieldName, `, err = `, p.Import(uuidImport), `.FromString(m.`, fieldName, `.Value)`)
+				p.P(`to.`, fieldName, `, err = `, p.uuidFromString(), `(m.`, fieldName, `.Value)`)
 				p.P(`if err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`} else {`)
-				p.P(`to.`, fieldName, ` = `, p.Import(uuidImport), `.Nil`)
+				p.P(`to.`, fieldName, ` = `, p.uuidNil())
 				p.P(`}`)
 			} else {
 				p.P(`to.`, fieldName, ` = &`, p.Import(gtypesImport), `.UUID{Value: m.`, fieldName, `.String()}`)
 			}
+		} else if wellKnownMessage(field) == protoTypeDuration && ofield != nil {
+			p.generateDurationConversion(field, ofield.Type, toORM)
+		} else if wellKnownMessage(field) == protoTypeFieldMask && ofield != nil {
+			p.generateFieldMaskConversion(field, fieldType, ofield.Type, toORM)
 		} else if coreType == protoTypeTimestamp { // Singular WKT Timestamp ---
 			if toORM {
 				p.P(`if m.`, fieldName, ` != nil {`)
@@ -797,10 +1081,10 @@ func (p *OrmPlugin) generateFieldConversion(message *generator.Descriptor, field
 				p.P(`}`)
 			}
 		} else if coreType == protoTypeJSON {
-			if p.dbEngine == ENGINE_POSTGRES {
+			if p.jsonColumnType() != "" {
 				if toORM {
 					p.P(`if m.`, fieldName, ` != nil {`)
-					p.P(`to.`, fieldName, ` = &`, p.Import(gormpqImport), `.Jsonb{[]byte(m.`, fieldName, `.Value)}`)
+					p.P(`to.`, fieldName, ` = &`, p.jsonType(), `{[]byte(m.`, fieldName, `.Value)}`)
 					p.P(`}`)
 				} else {
 					p.P(`if m.`, fieldName, ` != nil {`)
@@ -875,10 +1159,14 @@ func (p *OrmPlugin) generateFieldConversion(message *generator.Descriptor, field
 					p.P(`}`)
 				}
 			}
-		} else if coreType == protoTypeInet { // Inet type for Postgres only, currently
+		} else if coreType == protoTypeInet { // Inet type for Postgres, MySQL and SQLite only, currently
 			if toORM {
+				parseInet := "ParseInet"
+				if p.dbEngine == ENGINE_MYSQL {
+					parseInet = "ParseBinaryInet"
+				}
 				p.P(`if m.`, fieldName, ` != nil {`)
-				p.P(`if to.`, fieldName, `, err = `, p.Import(gtypesImport), `.ParseInet(m.`, fieldName, `.Value); err != nil {`)
+				p.P(`if to.`, fieldName, `, err = `, p.Import(gtypesImport), `.`, parseInet, `(m.`, fieldName, `.Value); err != nil {`)
 				p.P(`return to, err`)
 				p.P(`}`)
 				p.P(`}`)
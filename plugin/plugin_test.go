@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	plugin_go "github.com/gogo/protobuf/protoc-gen-gogo/plugin"
+	"github.com/gogo/protobuf/vanity/command"
+)
+
+// wktMappings maps the well-known types to the Go packages of
+// github.com/golang/protobuf, as the Makefile does
+const wktMappings = "Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers," +
+	"Mgoogle/protobuf/timestamp.proto=github.com/golang/protobuf/ptypes/timestamp," +
+	"Mgoogle/protobuf/duration.proto=github.com/golang/protobuf/ptypes/duration," +
+	"Mgoogle/protobuf/struct.proto=github.com/golang/protobuf/ptypes/struct," +
+	"Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any," +
+	"Mgoogle/protobuf/empty.proto=github.com/golang/protobuf/ptypes/empty," +
+	"Mgoogle/protobuf/field_mask.proto=google.golang.org/genproto/protobuf/field_mask," +
+	"Mgoogle/protobuf/descriptor.proto=github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+// fixtureParams are the parameters testdata/demo.proto is generated with, every
+// engine with both GORM versions and the options changing the output. The
+// migrations are diffed against testdata/previous/demo.proto
+var fixtureParams = []string{
+	"",
+	"gorm_version=v2",
+	"engine=postgres",
+	"engine=postgres,gorm_version=v2",
+	"engine=postgres,enums=string,gateway,migrations=sql",
+	"engine=postgres,migrations_from=testdata/previous/demo.fds",
+	"engine=mysql",
+	"engine=mysql,gorm_version=v2",
+	"engine=mysql,mysql_uuid=binary,migrations=sql",
+	"engine=mysql,migrations_from=testdata/previous/demo.fds",
+	"engine=sqlite",
+	"engine=sqlite,gorm_version=v2,enums=string,migrations=sql",
+	"engine=sqlite,migrations_from=testdata/previous/demo.fds",
+}
+
+// TestGenerateFixture generates testdata/demo.proto, whose descriptor set is
+// testdata/demo.fds, and type-checks the generated files along with the
+// protoc-gen-gogo output. The generator exits on errors, so each generation
+// runs in a process of its own
+func TestGenerateFixture(t *testing.T) {
+	if param, ok := os.LookupEnv("GORM_FIXTURE_PARAM"); ok {
+		generateFixture(t, param)
+		return
+	}
+	for _, param := range fixtureParams {
+		param := param
+		t.Run(param, func(t *testing.T) {
+			t.Parallel()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestGenerateFixture$")
+			cmd.Env = append(os.Environ(), "GORM_FIXTURE_PARAM="+param)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				if len(out) > 4000 {
+					out = append(out[:2000:2000], append([]byte("\n...\n"), out[len(out)-2000:]...)...)
+				}
+				t.Fatalf("%v\n%s", err, out)
+			}
+		})
+	}
+}
+
+func generateFixture(t *testing.T, param string) {
+	fds := readDescriptorSet(t, "testdata/demo.fds")
+	pbParam := "plugins=grpc," + wktMappings
+	pbResp := command.Generate(&plugin_go.CodeGeneratorRequest{FileToGenerate: []string{"demo.proto"}, Parameter: &pbParam, ProtoFile: fds.File})
+	if pbResp.Error != nil {
+		t.Fatal(pbResp.GetError())
+	}
+	param = strings.TrimPrefix(param+",quiet,"+wktMappings, ",")
+	req := &plugin_go.CodeGeneratorRequest{FileToGenerate: []string{"demo.proto"}, Parameter: &param, ProtoFile: fds.File}
+	op := &OrmPlugin{}
+	resp := command.GeneratePlugin(req, op, ".pb.gorm.go")
+	op.CleanFiles(resp)
+	op.AddMigrationFiles(resp)
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	fset := token.NewFileSet()
+	var goFiles []*ast.File
+	// the SQL files by kind, schema, up or down
+	sqlFiles := map[string]string{}
+	for _, f := range append(pbResp.File, resp.File...) {
+		switch {
+		case strings.HasSuffix(f.GetName(), ".go"):
+			file, err := parser.ParseFile(fset, f.GetName(), f.GetContent(), parser.AllErrors)
+			if err != nil {
+				t.Fatalf("%s: %v", f.GetName(), err)
+			}
+			goFiles = append(goFiles, file)
+		case strings.HasSuffix(f.GetName(), ".sql"):
+			sqlFiles[strings.TrimPrefix(filepath.Ext(strings.TrimSuffix(f.GetName(), ".sql")), ".")] = f.GetContent()
+		}
+	}
+	if len(goFiles) != 2 {
+		t.Fatalf("generated %d Go files, want 2", len(goFiles))
+	}
+	conf := types.Config{
+		Importer: &stubImporter{fset: fset, packages: map[string]*types.Package{}},
+		Error:    func(err error) { t.Error(err) },
+	}
+	conf.Check("github.com/suutaku/protoc-gen-gorm/plugin/testdata", fset, goFiles, nil)
+	if strings.Contains(param, "migrations=sql") && !strings.Contains(sqlFiles["schema"], "CREATE TABLE") {
+		t.Errorf("generated no schema:\n%s", sqlFiles["schema"])
+	}
+	if strings.Contains(param, "migrations_from") {
+		// testdata/previous/demo.proto has the people table and its full_name
+		// column since renamed, and the legacies table since dropped
+		for _, script := range []string{"up", "down"} {
+			for _, statement := range []string{"RENAME TO", "RENAME COLUMN", "ADD COLUMN", "DROP COLUMN", "CREATE TABLE", "DROP TABLE"} {
+				if !strings.Contains(sqlFiles[script], statement) {
+					t.Errorf("%q missing in the %s migration:\n%s", statement, script, sqlFiles[script])
+				}
+			}
+		}
+	}
+}
+
+func readDescriptorSet(t *testing.T, name string) *descriptor.FileDescriptorSet {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		t.Fatal(err)
+	}
+	return fds
+}
+
+// stubImporter type-checks the imported packages from source. The packages
+// missing from go.mod are the stubs of testdata/stubs, which only declare what
+// the generated code uses
+type stubImporter struct {
+	fset     *token.FileSet
+	packages map[string]*types.Package
+}
+
+func (im *stubImporter) Import(path string) (*types.Package, error) {
+	return im.ImportFrom(path, ".", 0)
+}
+
+func (im *stubImporter) ImportFrom(path, dir string, _ types.ImportMode) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	var files []string
+	if stubs, _ := filepath.Glob(filepath.Join("testdata", "stubs", path, "*.go")); len(stubs) > 0 {
+		files = stubs
+	} else {
+		ctxt := build.Default
+		ctxt.CgoEnabled = false
+		bp, err := ctxt.Import(path, dir, 0)
+		if err != nil {
+			return nil, err
+		}
+		path = bp.ImportPath
+		for _, name := range bp.GoFiles {
+			files = append(files, filepath.Join(bp.Dir, name))
+		}
+	}
+	if pkg, ok := im.packages[path]; ok {
+		return pkg, nil
+	}
+	var parsed []*ast.File
+	for _, name := range files {
+		file, err := parser.ParseFile(im.fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, file)
+	}
+	conf := types.Config{Importer: im, IgnoreFuncBodies: true}
+	pkg, err := conf.Check(path, im.fset, parsed, nil)
+	if err != nil {
+		return nil, err
+	}
+	im.packages[path] = pkg
+	return pkg, nil
+}
@@ -1,5 +1,12 @@
 package plugin
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+)
+
 // IsAbleToMakePQArray tells us if the specific field-type can automatically be turned into a PQ array:
 func (p *OrmPlugin) IsAbleToMakePQArray(fieldType string) bool {
 	switch fieldType {
@@ -11,7 +18,90 @@ func (p *OrmPlugin) IsAbleToMakePQArray(fieldType string) bool {
 		return true
 	case "[]string":
 		return true
+	case "[]int32", "[]uint32", "[]float32", "[][]byte":
+		return true
 	default:
 		return false
 	}
 }
+
+// jsonArrayTypes names the types.JSON<Name>Array storing each element type
+var jsonArrayTypes = map[string]string{
+	"bool":    "Bool",
+	"float32": "Float32",
+	"float64": "Float64",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"string":  "String",
+	"[]byte":  "Bytes",
+}
+
+// arrayElemType returns the Go type of the elements of a repeated scalar or
+// enum field in the ORM type, enums are stored as their number or name
+func (p *OrmPlugin) arrayElemType(field *descriptor.FieldDescriptorProto, fieldType string) string {
+	if field.IsEnum() {
+		if p.stringEnums {
+			return "string"
+		}
+		return "int32"
+	}
+	return strings.TrimPrefix(fieldType, "[]")
+}
+
+// arrayType returns the ORM type and the column type of a repeated scalar,
+// enum, UUID or timestamp field for the selected DB engine, both are empty if
+// it cannot be stored
+func (p *OrmPlugin) arrayType(field *descriptor.FieldDescriptorProto, fieldType string) (string, string) {
+	if !field.IsRepeated() {
+		return "", ""
+	}
+	if field.IsMessage() {
+		// Postgres has arrays of UUIDs and timestamps
+		if p.dbEngine != ENGINE_POSTGRES || p.isOrmable(fieldType) {
+			return "", ""
+		}
+		switch fieldType[strings.LastIndex(fieldType, ".")+1:] {
+		case protoTypeUUID:
+			return fmt.Sprintf("%s.PGUUIDArray", p.Import(gtypesImport)), "uuid[]"
+		case protoTypeTimestamp:
+			return fmt.Sprintf("%s.PGTimeArray", p.Import(gtypesImport)), "timestamptz[]"
+		}
+		return "", ""
+	}
+	elemType := p.arrayElemType(field, fieldType)
+	if p.dbEngine == ENGINE_POSTGRES && p.IsAbleToMakePQArray("[]"+elemType) {
+		switch elemType {
+		case "int32":
+			return fmt.Sprintf("%s.PGInt32Array", p.Import(gtypesImport)), "integer[]"
+		case "uint32":
+			return fmt.Sprintf("%s.PGUint32Array", p.Import(gtypesImport)), "bigint[]"
+		case "float32":
+			return fmt.Sprintf("%s.PGFloat32Array", p.Import(gtypesImport)), "real[]"
+		case "[]byte":
+			return fmt.Sprintf("%s.PGBytesArray", p.Import(gtypesImport)), "bytea[]"
+		case "bool":
+			return fmt.Sprintf("%s.BoolArray", p.Import(pqImport)), "bool[]"
+		case "float64":
+			return fmt.Sprintf("%s.Float64Array", p.Import(pqImport)), "float[]"
+		case "int64":
+			return fmt.Sprintf("%s.Int64Array", p.Import(pqImport)), "integer[]"
+		case "string":
+			return fmt.Sprintf("%s.StringArray", p.Import(pqImport)), "text[]"
+		}
+	}
+	name, ok := jsonArrayTypes[elemType]
+	if !ok {
+		return "", ""
+	}
+	// the other element types and engines store a JSON document
+	columnType := "text"
+	switch p.dbEngine {
+	case ENGINE_POSTGRES:
+		columnType = "jsonb"
+	case ENGINE_MYSQL:
+		columnType = "json"
+	}
+	return fmt.Sprintf("%s.JSON%sArray", p.Import(gtypesImport), name), columnType
+}
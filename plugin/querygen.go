@@ -0,0 +1,256 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+)
+
+// generateSchema generates the table and column name constants of the ORM
+// type, and its schema.Table, built like the tables of the SQL migrations
+func (p *OrmPlugin) generateSchema(message *generator.Descriptor) {
+	ormable := p.getOrmable(p.TypeName(message))
+	// the migrations warn about the types they don't know the columns of
+	defer func(suppressWarn bool) { p.suppressWarn = suppressWarn }(p.suppressWarn)
+	p.suppressWarn = true
+	table := p.buildTable(ormable)
+	pkNames := p.primaryKeyNames(ormable)
+	fieldNames := p.columnFieldNames(ormable)
+
+	p.P(`// `, ormable.Name, `Table is the name of the table of `, ormable.Name)
+	p.P(`const `, ormable.Name, `Table = "`, ormable.TableName, `"`)
+	p.P()
+	if len(fieldNames) == 0 {
+		return
+	}
+	p.P(`// Columns of `, ormable.Name)
+	p.P(`const (`)
+	for _, fieldName := range fieldNames {
+		p.P(ormable.Name, `Column`, fieldName, ` = "`, columnName(fieldName, ormable.Fields[fieldName]), `"`)
+	}
+	p.P(`)`)
+	p.P()
+
+	p.P(`// `, ormable.Name, `Schema describes the columns of `, ormable.Name)
+	p.P(`var `, ormable.Name, `Schema = `, p.Import(schemaImport), `.Table{`)
+	p.P(`Name: `, ormable.Name, `Table,`)
+	p.P(`Columns: []`, p.Import(schemaImport), `.Column{`)
+	for _, fieldName := range fieldNames {
+		name := columnName(fieldName, ormable.Fields[fieldName])
+		column := table.column(name)
+		var indexes, uniqueIndexes []string
+		for _, idx := range table.indexes {
+			if !inStrings(idx.columns, name) {
+				continue
+			}
+			if idx.unique {
+				uniqueIndexes = append(uniqueIndexes, strconv.Quote(idx.name))
+			} else {
+				indexes = append(indexes, strconv.Quote(idx.name))
+			}
+		}
+		desc := fmt.Sprintf(`Field: %q, Name: %sColumn%s, Type: %q, Nullable: %t`, fieldName, ormable.Name, fieldName, column.typ, !column.notNull)
+		if inStrings(pkNames, fieldName) {
+			desc += `, PrimaryKey: true`
+		}
+		if len(indexes) > 0 {
+			desc += `, Indexes: []string{` + strings.Join(indexes, `, `) + `}`
+		}
+		if len(uniqueIndexes) > 0 {
+			desc += `, UniqueIndexes: []string{` + strings.Join(uniqueIndexes, `, `) + `}`
+		}
+		p.P(`{`, desc, `},`)
+	}
+	p.P(`},`)
+	p.P(`FieldPaths: map[string]string{`)
+	for _, field := range message.Field {
+		fieldName := generator.CamelCase(field.GetName())
+		if inStrings(fieldNames, fieldName) {
+			p.P(`"`, field.GetName(), `": `, ormable.Name, `Column`, fieldName, `,`)
+		}
+	}
+	p.P(`},`)
+	p.P(`}`)
+	p.P()
+}
+
+// generateQueryBuilder generates Query<Type>ORM, which builds the queries of
+// the ORM type from typed conditions on its columns
+func (p *OrmPlugin) generateQueryBuilder(message *generator.Descriptor) {
+	typeName := p.TypeName(message)
+	ormable := p.getOrmable(typeName)
+	queryName := ormable.Name + `Query`
+	gormDB := fmt.Sprint(`*`, p.Import(gormImport), `.DB`)
+
+	p.P(`// `, queryName, ` is a query of `, ormable.Name, ` rows, each method returns the query with one more clause`)
+	p.P(`type `, queryName, ` struct {`)
+	p.P(`db `, gormDB)
+	p.P(`}`)
+	p.P()
+	p.P(`// Query`, ormable.Name, ` starts a query of the `, ormable.Name, ` rows of db`)
+	p.P(`func Query`, ormable.Name, `(db `, gormDB, `) *`, queryName, ` {`)
+	p.P(`return &`, queryName, `{db: db.Model(&`, ormable.Name, `{})}`)
+	p.P(`}`)
+	p.P()
+	p.P(`// DB returns the gorm query, for the clauses the builder doesn't have`)
+	p.P(`func (q *`, queryName, `) DB() `, gormDB, ` {`)
+	p.P(`return q.db`)
+	p.P(`}`)
+	p.P()
+
+	for _, fieldName := range p.columnFieldNames(ormable) {
+		// GORM excludes the deleted rows, Unscoped includes them
+		if ormable.SoftDelete && fieldName == "DeletedAt" {
+			continue
+		}
+		p.generateQueryConditions(ormable, fieldName)
+	}
+
+	p.P(`// Limit returns at most n rows`)
+	p.P(`func (q *`, queryName, `) Limit(n int) *`, queryName, ` {`)
+	p.P(`return &`, queryName, `{db: q.db.Limit(n)}`)
+	p.P(`}`)
+	p.P()
+	p.P(`// Offset skips the first n rows`)
+	p.P(`func (q *`, queryName, `) Offset(n int) *`, queryName, ` {`)
+	p.P(`return &`, queryName, `{db: q.db.Offset(n)}`)
+	p.P(`}`)
+	p.P()
+	if ormable.SoftDelete {
+		p.P(`// Unscoped includes the deleted rows`)
+		p.P(`func (q *`, queryName, `) Unscoped() *`, queryName, ` {`)
+		p.P(`return &`, queryName, `{db: q.db.Unscoped()}`)
+		p.P(`}`)
+		p.P()
+	}
+
+	p.P(`// Find returns the `, typeName, ` objects of the rows`)
+	p.P(`func (q *`, queryName, `) Find(ctx context.Context) ([]*`, typeName, `, error) {`)
+	p.P(`var rows []`, ormable.Name)
+	p.P(`if err := q.db`, p.withContext(), `.Find(&rows).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`res := make([]*`, typeName, `, 0, len(rows))`)
+	p.P(`for _, row := range rows {`)
+	p.P(`pbObj, err := row.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`res = append(res, &pbObj)`)
+	p.P(`}`)
+	p.P(`return res, nil`)
+	p.P(`}`)
+	p.P()
+	p.P(`// First returns the `, typeName, ` object of the first row, gorm.ErrRecordNotFound if there are none`)
+	p.P(`func (q *`, queryName, `) First(ctx context.Context) (*`, typeName, `, error) {`)
+	p.P(`var row `, ormable.Name)
+	p.P(`if err := q.db`, p.withContext(), `.First(&row).Error; err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`pbObj, err := row.ToPB(ctx)`)
+	p.P(`if err != nil {`)
+	p.P(`return nil, err`)
+	p.P(`}`)
+	p.P(`return &pbObj, nil`)
+	p.P(`}`)
+	p.P()
+	p.P(`// Count returns the number of rows`)
+	p.P(`func (q *`, queryName, `) Count(ctx context.Context) (int64, error) {`)
+	p.P(`var count int64`)
+	p.P(`err := q.db`, p.withContext(), `.Count(&count).Error`)
+	p.P(`return count, err`)
+	p.P(`}`)
+	p.P()
+}
+
+// generateQueryConditions generates the Where and OrderBy methods of a
+// column, the operators depend on its type
+func (p *OrmPlugin) generateQueryConditions(ormable *OrmableType, fieldName string) {
+	field := ormable.Fields[fieldName]
+	queryName := ormable.Name + `Query`
+	column := ormable.Name + `Column` + fieldName
+	nullable := strings.HasPrefix(field.Type, "*")
+	argType := field.Type
+	// pointers to basic types are compared to values
+	if base := strings.TrimPrefix(field.Type, "*"); isOrderedType(base) || base == "bool" {
+		argType = base
+	}
+	condition := func(name, doc, op string) {
+		p.P(`// Where`, fieldName, name, ` selects the rows whose `, fieldName, ` `, doc)
+		p.P(`func (q *`, queryName, `) Where`, fieldName, name, `(v `, argType, `) *`, queryName, ` {`)
+		p.P(`return &`, queryName, `{db: q.db.Where(`, column, `+" `, op, ` ?", v)}`)
+		p.P(`}`)
+		p.P()
+	}
+	condition(`Eq`, `equals v`, `=`)
+	condition(`Neq`, `differs from v`, `<>`)
+	if isOrderedType(argType) {
+		condition(`Gt`, `is greater than v`, `>`)
+		condition(`Gte`, `is greater than or equal to v`, `>=`)
+		condition(`Lt`, `is less than v`, `<`)
+		condition(`Lte`, `is less than or equal to v`, `<=`)
+		p.P(`// Where`, fieldName, `In selects the rows whose `, fieldName, ` is one of vs`)
+		p.P(`func (q *`, queryName, `) Where`, fieldName, `In(vs ...`, argType, `) *`, queryName, ` {`)
+		p.P(`return &`, queryName, `{db: q.db.Where(`, column, `+" IN (?)", vs)}`)
+		p.P(`}`)
+		p.P()
+	}
+	if argType == "string" {
+		condition(`Like`, `matches the LIKE pattern v`, `LIKE`)
+	}
+	if nullable {
+		for _, null := range []string{`Null`, `NotNull`} {
+			op := strings.ToUpper(strings.Replace(null, `Not`, `Not `, 1))
+			p.P(`// Where`, fieldName, `Is`, null, ` selects the rows whose `, fieldName, ` is `, strings.ToLower(op))
+			p.P(`func (q *`, queryName, `) Where`, fieldName, `Is`, null, `() *`, queryName, ` {`)
+			p.P(`return &`, queryName, `{db: q.db.Where(`, column, `+" IS `, op, `")}`)
+			p.P(`}`)
+			p.P()
+		}
+	}
+	if isOrderedType(argType) || argType == "bool" {
+		p.P(`// OrderBy`, fieldName, ` sorts the rows by `, fieldName)
+		p.P(`func (q *`, queryName, `) OrderBy`, fieldName, `() *`, queryName, ` {`)
+		p.P(`return &`, queryName, `{db: q.db.Order(`, column, `)}`)
+		p.P(`}`)
+		p.P()
+		p.P(`// OrderBy`, fieldName, `Desc sorts the rows by `, fieldName, ` in descending order`)
+		p.P(`func (q *`, queryName, `) OrderBy`, fieldName, `Desc() *`, queryName, ` {`)
+		p.P(`return &`, queryName, `{db: q.db.Order(`, column, `+" desc")}`)
+		p.P(`}`)
+		p.P()
+	}
+}
+
+// columnFieldNames returns the sorted names of the fields of the ORM type
+// that are columns of its table
+func (p *OrmPlugin) columnFieldNames(ormable *OrmableType) []string {
+	var fieldNames []string
+	for _, fieldName := range p.getSortedFieldNames(ormable.Fields) {
+		field := ormable.Fields[fieldName]
+		if !isColumnField(field) || field.GetTag().GetEmbedded() {
+			continue
+		}
+		fieldNames = append(fieldNames, fieldName)
+	}
+	return fieldNames
+}
+
+// withContext returns the call passing the context to the queries of GORM
+// v2, GORM v1 has no context
+func (p *OrmPlugin) withContext() string {
+	if p.gormVersion == GORM_V2 {
+		return `.WithContext(ctx)`
+	}
+	return ``
+}
+
+func isOrderedType(goType string) bool {
+	switch goType {
+	case "string", "float32", "float64", "time.Time":
+		return true
+	}
+	return isIntegerType(goType) && !strings.HasPrefix(goType, "*")
+}
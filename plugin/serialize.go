@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	gorm "github.com/suutaku/protoc-gen-gorm/options"
+)
+
+// serializeFormat returns the format a message field is stored in, the one of
+// its serialize option or of the serialized well-known types
+func serializeFormat(field *descriptor.FieldDescriptorProto) gorm.SerializeFormat {
+	if format := getFieldOptions(field).GetSerialize(); format != gorm.SerializeFormat_NONE || field.IsRepeated() {
+		return format
+	}
+	return serializedWellKnownTypes[wellKnownMessage(field)]
+}
+
+// serializedType returns the ORM type, its package and the column type of a
+// serialized message field, all empty if it is not serialized. The JSON
+// documents are stored like the maps, the binary messages as []byte in a
+// bytea or blob column
+func (p *OrmPlugin) serializedType(field *descriptor.FieldDescriptorProto, fieldType string) (string, string, string) {
+	format := serializeFormat(field)
+	if format == gorm.SerializeFormat_NONE {
+		return "", "", ""
+	}
+	if !field.IsMessage() || field.IsRepeated() || p.isOrmable(fieldType) {
+		p.Fail("serialize option of field", field.GetName(), "is only supported for singular fields of non-ormable message types.")
+	}
+	if format == gorm.SerializeFormat_PROTO_BINARY {
+		return "[]byte", "", ""
+	}
+	return p.jsonDocumentType()
+}
+
+// generateSerializedConversion converts a serialized message field to or from
+// its ORM type, the JSON documents ignore the unknown fields when read so
+// that removing a field from the message keeps the rows readable
+func (p *OrmPlugin) generateSerializedConversion(field *descriptor.FieldDescriptorProto, fieldType, ormType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	binary := serializeFormat(field) == gorm.SerializeFormat_PROTO_BINARY
+	p.P(`if m.`, fieldName, ` != nil {`)
+	switch {
+	case toORM && binary:
+		p.P(`if to.`, fieldName, `, err = `, p.Import(protoImport), `.Marshal(m.`, fieldName, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		// an empty message is not a NULL column
+		p.P(`if to.`, fieldName, ` == nil {`)
+		p.P(`to.`, fieldName, ` = []byte{}`)
+		p.P(`}`)
+	case toORM:
+		p.P(`s, err := (&`, p.Import(jsonpbImport), `.Marshaler{}).MarshalToString(m.`, fieldName, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(ormType, "*"), `{[]byte(s)}`)
+	case binary:
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(fieldType, "*"), `{}`)
+		p.P(`if err = `, p.Import(protoImport), `.Unmarshal(m.`, fieldName, `, to.`, fieldName, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	default:
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(fieldType, "*"), `{}`)
+		p.UsingGoImports("bytes")
+		p.P(`unmarshaler := &`, p.Import(jsonpbImport), `.Unmarshaler{AllowUnknownFields: true}`)
+		p.P(`if err = unmarshaler.Unmarshal(bytes.NewReader(m.`, fieldName, `.RawMessage), to.`, fieldName, `); err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+	}
+	p.P(`}`)
+}
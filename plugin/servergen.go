@@ -7,16 +7,22 @@ import (
 
 	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
 	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	jgorm "github.com/jinzhu/gorm"
+	"github.com/jinzhu/inflection"
 )
 
 const (
 	createService    = "Create"
+	createSetService = "CreateSet"
 	readService      = "Read"
 	updateService    = "Update"
 	updateSetService = "UpdateSet"
 	deleteService    = "Delete"
 	deleteSetService = "DeleteSet"
+	upsertService    = "Upsert"
 	listService      = "List"
+	// server-streaming List methods
+	listStreamService = "ListStream"
 )
 
 type autogenService struct {
@@ -24,6 +30,7 @@ type autogenService struct {
 	ccName            string
 	file              *generator.FileDescriptor
 	usesTxnMiddleware bool
+	statusErrors      bool
 	methods           []autogenMethod
 	autogen           bool
 }
@@ -50,6 +57,7 @@ func (p *OrmPlugin) parseServices(file *generator.FileDescriptor) {
 		if opts := getServiceOptions(service); opts != nil {
 			genSvc.autogen = opts.GetAutogen()
 			genSvc.usesTxnMiddleware = opts.GetTxnMiddleware()
+			genSvc.statusErrors = opts.GetStatusErrors()
 		}
 		if !genSvc.autogen {
 			p.suppressWarn = true
@@ -58,7 +66,10 @@ func (p *OrmPlugin) parseServices(file *generator.FileDescriptor) {
 			inType, outType, methodName := p.getMethodProps(method)
 			var verb, fmName, baseType string
 			var follows bool
-			if strings.HasPrefix(methodName, createService) {
+			if strings.HasPrefix(methodName, createSetService) {
+				verb = createSetService
+				follows, baseType = p.followsCreateSetConventions(inType, outType, createSetService)
+			} else if strings.HasPrefix(methodName, createService) {
 				verb = createService
 				follows, baseType = p.followsCreateConventions(inType, outType, createService)
 			} else if strings.HasPrefix(methodName, readService) {
@@ -76,6 +87,12 @@ func (p *OrmPlugin) parseServices(file *generator.FileDescriptor) {
 			} else if strings.HasPrefix(methodName, deleteService) {
 				verb = deleteService
 				follows, baseType = p.followsDeleteConventions(inType, outType, method)
+			} else if strings.HasPrefix(methodName, upsertService) {
+				verb = upsertService
+				follows, baseType, fmName = p.followsUpsertConventions(inType, outType, upsertService)
+			} else if strings.HasPrefix(methodName, listService) && method.GetServerStreaming() {
+				verb = listStreamService
+				follows, baseType = p.followsListStreamConventions(inType, outType, methodName)
 			} else if strings.HasPrefix(methodName, listService) {
 				verb = listService
 				follows, baseType = p.followsListConventions(inType, outType, listService)
@@ -94,6 +111,9 @@ func (p *OrmPlugin) parseServices(file *generator.FileDescriptor) {
 
 			if genMethod.verb != "" && p.isOrmable(genMethod.baseType) {
 				p.getOrmable(genMethod.baseType).Methods[genMethod.verb] = &genMethod
+				if !genSvc.usesTxnMiddleware && getServiceOptions(service).GetHandlerTransactions() {
+					p.getOrmable(genMethod.baseType).OwnTransactions = true
+				}
 			}
 		}
 		p.ormableServices = append(p.ormableServices, genSvc)
@@ -110,7 +130,13 @@ func (p *OrmPlugin) generateDefaultServer(file *generator.FileDescriptor) {
 		if !service.usesTxnMiddleware {
 			p.P(`DB *`, p.Import(gormImport), `.DB`)
 		}
+		if service.statusErrors {
+			p.P(`ErrorMapper `, service.ccName, `ErrorMapper`)
+		}
 		p.P(`}`)
+		if service.statusErrors {
+			p.generateErrorMapper(service)
+		}
 		withSpan := getServiceOptions(service.ServiceDescriptorProto).WithTracing
 		if withSpan {
 			p.generateSpanInstantiationMethod(service)
@@ -123,6 +149,8 @@ func (p *OrmPlugin) generateDefaultServer(file *generator.FileDescriptor) {
 			switch method.verb {
 			case createService:
 				p.generateCreateServerMethod(service, method)
+			case createSetService:
+				p.generateCreateSetServerMethod(service, method)
 			case readService:
 				p.generateReadServerMethod(service, method)
 			case updateService:
@@ -133,8 +161,12 @@ func (p *OrmPlugin) generateDefaultServer(file *generator.FileDescriptor) {
 				p.generateDeleteServerMethod(service, method)
 			case deleteSetService:
 				p.generateDeleteSetServerMethod(service, method)
+			case upsertService:
+				p.generateUpsertServerMethod(service, method)
 			case listService:
 				p.generateListServerMethod(service, method)
+			case listStreamService:
+				p.generateListStreamServerMethod(service, method)
 			default:
 				p.generateMethodStub(service, method)
 			}
@@ -179,6 +211,7 @@ func (p *OrmPlugin) generateSpanResultMethod(service autogenService) {
 }
 
 func (p *OrmPlugin) wrapSpanError(service autogenService, errVarName string) string {
+	errVarName = p.wrapStatusError(service, errVarName)
 	withSpan := getServiceOptions(service.ServiceDescriptorProto).WithTracing
 	if withSpan {
 		return fmt.Sprint(`m.spanError(span, `, errVarName, `)`)
@@ -186,6 +219,60 @@ func (p *OrmPlugin) wrapSpanError(service autogenService, errVarName string) str
 	return errVarName
 }
 
+// wrapStatusError converts the error into a gRPC status if the service has the
+// status_errors option
+func (p *OrmPlugin) wrapStatusError(service autogenService, errVarName string) string {
+	if service.statusErrors {
+		return fmt.Sprint(`m.mapError(ctx, `, errVarName, `)`)
+	}
+	return errVarName
+}
+
+// generateErrorMapper generates the interface converting the errors of the
+// service methods into gRPC statuses, and the default conversion used when
+// the DefaultServer has no ErrorMapper
+func (p *OrmPlugin) generateErrorMapper(service autogenService) {
+	p.UsingGoImports("errors")
+	status, codes := p.Import(grpcStatusImport), p.Import(grpcCodesImport)
+	p.P(`// `, service.ccName, `ErrorMapper converts the errors of `, service.ccName, `DefaultServer into gRPC statuses`)
+	p.P(`type `, service.ccName, `ErrorMapper interface {`)
+	p.P(`MapError(context.Context, error) error`)
+	p.P(`}`)
+	p.P()
+	p.P(`// Default`, service.ccName, `MapError converts the errors of `, service.ccName, `DefaultServer when it has no ErrorMapper`)
+	p.P(`func Default`, service.ccName, `MapError(ctx context.Context, err error) error {`)
+	p.P(`if _, ok := `, status, `.FromError(err); ok {`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P(`switch {`)
+	p.P(`case errors.Is(err, `, p.Import(gormImport), `.ErrRecordNotFound):`)
+	p.P(`return `, status, `.Error(`, codes, `.NotFound, err.Error())`)
+	p.P(`case errors.Is(err, `, p.Import(gerrorsImport), `.EmptyIdError), errors.Is(err, `, p.Import(gerrorsImport), `.NilArgumentError), errors.Is(err, `, p.Import(gerrorsImport), `.InvalidFieldError):`)
+	p.P(`return `, status, `.Error(`, codes, `.InvalidArgument, err.Error())`)
+	p.P(`case errors.Is(err, `, p.Import(gerrorsImport), `.ConflictError):`)
+	p.P(`return `, status, `.Error(`, codes, `.Aborted, err.Error())`)
+	p.P(`case errors.Is(err, `, p.Import(gerrorsImport), `.NoTransactionError):`)
+	p.P(`return `, status, `.Error(`, codes, `.Internal, err.Error())`)
+	p.P(`case `, p.Import(gerrorsImport), `.IsUniqueViolation(err):`)
+	p.P(`return `, status, `.Error(`, codes, `.AlreadyExists, err.Error())`)
+	p.P(`case `, p.Import(gerrorsImport), `.IsForeignKeyViolation(err):`)
+	p.P(`return `, status, `.Error(`, codes, `.FailedPrecondition, err.Error())`)
+	p.P(`case errors.Is(err, context.Canceled):`)
+	p.P(`return `, status, `.Error(`, codes, `.Canceled, err.Error())`)
+	p.P(`case errors.Is(err, context.DeadlineExceeded):`)
+	p.P(`return `, status, `.Error(`, codes, `.DeadlineExceeded, err.Error())`)
+	p.P(`}`)
+	p.P(`return err`)
+	p.P(`}`)
+	p.P()
+	p.P(`func (m *`, service.ccName, `DefaultServer) mapError(ctx context.Context, err error) error {`)
+	p.P(`if m.ErrorMapper != nil {`)
+	p.P(`return m.ErrorMapper.MapError(ctx, err)`)
+	p.P(`}`)
+	p.P(`return Default`, service.ccName, `MapError(ctx, err)`)
+	p.P(`}`)
+}
+
 func (p *OrmPlugin) generateCreateServerMethod(service autogenService, method autogenMethod) {
 	p.generateMethodSignature(service, method)
 	if method.followsConvention {
@@ -246,6 +333,69 @@ func (p *OrmPlugin) followsCreateConventions(inType generator.Object, outType ge
 	return true, inTypeName
 }
 
+func (p *OrmPlugin) generateCreateSetServerMethod(service autogenService, method autogenMethod) {
+	p.generateMethodSignature(service, method)
+	if method.followsConvention {
+		p.generateDBSetup(service)
+		p.generatePreserviceCall(service, method.baseType, method.ccName)
+		batchSize := getMethodOptions(method.MethodDescriptorProto).GetBatchSize()
+		p.P(`res, err := DefaultCreate`, method.baseType, `Set(ctx, in.GetObjects(), db, `, fmt.Sprint(batchSize), `)`)
+		p.P(`if err != nil {`)
+		p.P(`return nil, `, p.wrapSpanError(service, "err"))
+		p.P(`}`)
+		p.P(`out := &`, p.TypeName(method.outType), `{Results: res}`)
+		if p.gateway {
+			p.P(`err = `, p.Import(gatewayImport), `.SetCreated(ctx, "")`)
+			p.P(`if err != nil {`)
+			p.P(`return nil, `, p.wrapSpanError(service, "err"))
+			p.P(`}`)
+		}
+		p.generatePostserviceCall(service, method.baseType, method.ccName)
+		p.spanResultHandling(service)
+		p.P(`return out, nil`)
+		p.P(`}`)
+		p.generatePreserviceHook(service.ccName, method.baseType, method.ccName)
+		p.generatePostserviceHook(service.ccName, method.baseType, p.TypeName(method.outType), method.ccName)
+	} else {
+		p.generateEmptyBody(service, method.outType)
+	}
+}
+
+func (p *OrmPlugin) followsCreateSetConventions(inType generator.Object, outType generator.Object, methodName string) (bool, string) {
+	inMsg, outMsg := inType.(*generator.Descriptor), outType.(*generator.Descriptor)
+	var inEntity, outEntity *descriptor.FieldDescriptorProto
+	for _, f := range inMsg.Field {
+		if f.GetName() == "objects" {
+			inEntity = f
+		}
+	}
+	for _, f := range outMsg.Field {
+		if f.GetName() == "results" {
+			outEntity = f
+		}
+	}
+	if inEntity == nil || outEntity == nil || !inEntity.IsRepeated() || !outEntity.IsRepeated() {
+		p.warning(`stub will be generated for %s since %s incoming message doesn't have repeated "objects" field or %s outcoming message doesn't have repeated "results" field`, methodName, p.TypeName(inType), p.TypeName(outType))
+		return false, ""
+	}
+	inGoType, _ := p.GoType(inMsg, inEntity)
+	outGoType, _ := p.GoType(outMsg, outEntity)
+	inTypeName, outTypeName := strings.TrimPrefix(inGoType, "[]*"), strings.TrimPrefix(outGoType, "[]*")
+	if !p.isOrmable(inTypeName) {
+		p.warning(`stub will be generated for %s since %s is not an ormable type`, methodName, inTypeName)
+		return false, ""
+	}
+	if inTypeName != outTypeName {
+		p.warning(`stub will be generated for %s since "objects" field type of %s incoming message type doesn't match "results" field type of %s outcoming message`, methodName, p.TypeName(inType), p.TypeName(outType))
+		return false, ""
+	}
+	if reason := p.createSetUnsupported(p.getOrmable(inTypeName)); reason != "" {
+		p.warning(`stub will be generated for %s since %s`, methodName, reason)
+		return false, ""
+	}
+	return true, inTypeName
+}
+
 func (p *OrmPlugin) generateReadServerMethod(service autogenService, method autogenMethod) {
 	p.generateMethodSignature(service, method)
 	if method.followsConvention {
@@ -253,9 +403,9 @@ func (p *OrmPlugin) generateReadServerMethod(service autogenService, method auto
 		p.generatePreserviceCall(service, method.baseType, method.ccName)
 		typeName := method.baseType
 		if fields := p.getFieldSelection(method.inType); fields != "" {
-			p.P(`res, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{Id: in.GetId()}, db, in.`, fields, `)`)
+			p.P(`res, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{`, p.keyLiteral(p.getOrmable(typeName), "in"), `}, db, in.`, fields, `)`)
 		} else {
-			p.P(`res, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{Id: in.GetId()}, db)`)
+			p.P(`res, err := DefaultRead`, typeName, `(ctx, &`, typeName, `{`, p.keyLiteral(p.getOrmable(typeName), "in"), `}, db)`)
 		}
 		p.P(`if err != nil {`)
 		p.P(`return nil, `, p.wrapSpanError(service, "err"))
@@ -275,16 +425,6 @@ func (p *OrmPlugin) generateReadServerMethod(service autogenService, method auto
 func (p *OrmPlugin) followsReadConventions(inType generator.Object, outType generator.Object, methodName string) (bool, string) {
 	inMsg := inType.(*generator.Descriptor)
 	outMsg := outType.(*generator.Descriptor)
-	var hasID bool
-	for _, field := range inMsg.Field {
-		if field.GetName() == "id" {
-			hasID = true
-		}
-	}
-	if !hasID {
-		p.warning(`stub will be generated for %s since %s incoming message doesn't have "id" field`, methodName, p.TypeName(inType))
-		return false, ""
-	}
 	var outTypeName string
 	var typeOrmable bool
 	for _, field := range outMsg.Field {
@@ -304,9 +444,39 @@ func (p *OrmPlugin) followsReadConventions(inType generator.Object, outType gene
 		p.warning(`stub will be generated for %s since %s ormable type doesn't have a primary key`, methodName, outTypeName)
 		return false, ""
 	}
+	if missing := p.missingKeyField(inMsg, outTypeName, false); missing != "" {
+		p.warning(`stub will be generated for %s since %s incoming message doesn't have %q field`, methodName, p.TypeName(inType), missing)
+		return false, ""
+	}
 	return true, outTypeName
 }
 
+// missingKeyField returns the name of a field identifying the objects of the
+// ormable type the request message doesn't have, "id" or, for a composite
+// key, the field of each key column. The fields of the requests deleting
+// several objects are repeated and named in the plural
+func (p *OrmPlugin) missingKeyField(inMsg *generator.Descriptor, typeName string, repeated bool) string {
+	keys := []string{"Id"}
+	if pkNames := p.primaryKeyFields(p.getOrmable(typeName)); len(pkNames) > 1 {
+		keys = pkNames
+	}
+	for _, key := range keys {
+		if repeated {
+			key = inflection.Plural(key)
+		}
+		found := false
+		for _, field := range inMsg.Field {
+			if generator.CamelCase(field.GetName()) == key && field.IsRepeated() == repeated {
+				found = true
+			}
+		}
+		if !found {
+			return jgorm.ToDBName(key)
+		}
+	}
+	return ""
+}
+
 func (p *OrmPlugin) generateUpdateServerMethod(service autogenService, method autogenMethod) {
 	p.generateMethodSignature(service, method)
 	if method.followsConvention {
@@ -324,6 +494,7 @@ func (p *OrmPlugin) generateUpdateServerMethod(service autogenService, method au
 		} else {
 			p.P(`res, err = DefaultStrictUpdate`, typeName, `(ctx, in.GetPayload(), db)`)
 		}
+		p.generateConflictStatus(typeName)
 		p.P(`if err != nil {`)
 		p.P(`return nil, `, p.wrapSpanError(service, "err"))
 		p.P(`}`)
@@ -339,6 +510,34 @@ func (p *OrmPlugin) generateUpdateServerMethod(service autogenService, method au
 	}
 }
 
+// generateConflictStatus turns the version conflict of an update into an
+// Aborted status, so that the client can read the object again and retry
+func (p *OrmPlugin) generateConflictStatus(typeName string) {
+	if p.getOrmable(typeName).VersionField == "" {
+		return
+	}
+	p.UsingGoImports("errors")
+	p.P(`if errors.Is(err, `, p.Import(gerrorsImport), `.ConflictError) {`)
+	p.P(`err = `, p.Import(grpcStatusImport), `.Error(`, p.Import(grpcCodesImport), `.Aborted, err.Error())`)
+	p.P(`}`)
+}
+
+// generateInvalidFieldStatus turns the error of a List request filtering or
+// sorting by a field the type doesn't allow into an InvalidArgument status
+func (p *OrmPlugin) generateInvalidFieldStatus(typeName string) {
+	restricted := false
+	for _, field := range p.getOrmable(typeName).Fields {
+		restricted = restricted || field.GetFilterable() || field.GetSortable()
+	}
+	if !restricted {
+		return
+	}
+	p.UsingGoImports("errors")
+	p.P(`if errors.Is(err, `, p.Import(gerrorsImport), `.InvalidFieldError) {`)
+	p.P(`err = `, p.Import(grpcStatusImport), `.Error(`, p.Import(grpcCodesImport), `.InvalidArgument, err.Error())`)
+	p.P(`}`)
+}
+
 func (p *OrmPlugin) followsUpdateConventions(inType generator.Object, outType generator.Object, methodName string) (bool, string, string) {
 	inMsg := inType.(*generator.Descriptor)
 	outMsg := outType.(*generator.Descriptor)
@@ -386,13 +585,52 @@ func (p *OrmPlugin) followsUpdateConventions(inType generator.Object, outType ge
 	return true, inTypeName, generator.CamelCase(updateMask)
 }
 
+func (p *OrmPlugin) generateUpsertServerMethod(service autogenService, method autogenMethod) {
+	p.generateMethodSignature(service, method)
+	if method.followsConvention {
+		p.generateDBSetup(service)
+		p.generatePreserviceCall(service, method.baseType, method.ccName)
+		updateMask := "nil"
+		if method.fieldMaskName != "" {
+			updateMask = fmt.Sprint(`in.Get`, method.fieldMaskName, `()`)
+		}
+		p.P(`res, err := DefaultUpsert`, method.baseType, `(ctx, in.GetPayload(), `, updateMask, `, db)`)
+		p.P(`if err != nil {`)
+		p.P(`return nil, `, p.wrapSpanError(service, "err"))
+		p.P(`}`)
+		p.P(`out := &`, p.TypeName(method.outType), `{Result: res}`)
+		p.generatePostserviceCall(service, method.baseType, method.ccName)
+		p.spanResultHandling(service)
+		p.P(`return out, nil`)
+		p.P(`}`)
+		p.generatePreserviceHook(service.ccName, method.baseType, method.ccName)
+		p.generatePostserviceHook(service.ccName, method.baseType, p.TypeName(method.outType), method.ccName)
+	} else {
+		p.generateEmptyBody(service, method.outType)
+	}
+}
+
+// followsUpsertConventions expects the request and the response of an Update
+// method, and an ormable type DefaultUpsert is generated for
+func (p *OrmPlugin) followsUpsertConventions(inType generator.Object, outType generator.Object, methodName string) (bool, string, string) {
+	follows, typeName, updateMask := p.followsUpdateConventions(inType, outType, methodName)
+	if !follows {
+		return false, "", ""
+	}
+	if _, reason := p.upsertTarget(p.getOrmable(typeName)); reason != "" {
+		p.warning(`stub will be generated for %s since %s`, methodName, reason)
+		return false, "", ""
+	}
+	return true, typeName, updateMask
+}
+
 func (p *OrmPlugin) generateUpdateSetServerMethod(service autogenService, method autogenMethod) {
 	p.generateMethodSignature(service, method)
 	if method.followsConvention {
 		typeName := method.baseType
 		typeName = strings.TrimPrefix(typeName, "[]*")
 		p.P(`if in == nil {`)
-		p.P(`return nil,`, p.Import(gerrorsImport), `.NilArgumentError`)
+		p.P(`return nil, `, p.wrapStatusError(service, p.Import(gerrorsImport)+`.NilArgumentError`))
 		p.P(`}`)
 		p.P(``)
 		p.generateDBSetup(service)
@@ -401,6 +639,7 @@ func (p *OrmPlugin) generateUpdateSetServerMethod(service autogenService, method
 
 		p.P(``)
 		p.P(`res, err := DefaultPatchSet`, typeName, `(ctx, in.GetObjects(), in.Get`, method.fieldMaskName, `(), db)`)
+		p.generateConflictStatus(typeName)
 		p.P(`if err != nil {`)
 		p.P(`return nil, `, p.wrapSpanError(service, "err"))
 		p.P(`}`)
@@ -494,7 +733,7 @@ func (p *OrmPlugin) generateDeleteServerMethod(service autogenService, method au
 		typeName := method.baseType
 		p.generateDBSetup(service)
 		p.generatePreserviceCall(service, method.baseType, method.ccName)
-		p.P(`err := DefaultDelete`, typeName, `(ctx, &`, typeName, `{Id: in.GetId()}, db)`)
+		p.P(`err := DefaultDelete`, typeName, `(ctx, &`, typeName, `{`, p.keyLiteral(p.getOrmable(typeName), "in"), `}, db)`)
 		p.P(`if err != nil {`)
 		p.P(`return nil, `, p.wrapSpanError(service, "err"))
 		p.P(`}`)
@@ -513,16 +752,6 @@ func (p *OrmPlugin) generateDeleteServerMethod(service autogenService, method au
 func (p *OrmPlugin) followsDeleteConventions(inType generator.Object, outType generator.Object, method *descriptor.MethodDescriptorProto) (bool, string) {
 	inMsg := inType.(*generator.Descriptor)
 	methodName := generator.CamelCase(method.GetName())
-	var hasID bool
-	for _, field := range inMsg.Field {
-		if field.GetName() == "id" {
-			hasID = true
-		}
-	}
-	if !hasID {
-		p.warning(`stub will be generated for %s since %s incoming message doesn't have "id" field`, methodName, p.TypeName(inType))
-		return false, ""
-	}
 	typeName := generator.CamelCase(getMethodOptions(method).GetObjectType())
 	if typeName == "" {
 		p.warning(`stub will be generated for %s since (gorm.method).object_type option is not specified`, methodName)
@@ -536,6 +765,10 @@ func (p *OrmPlugin) followsDeleteConventions(inType generator.Object, outType ge
 		p.warning(`stub will be generated for %s since %s ormable type doesn't have a primary key`, methodName, typeName)
 		return false, ""
 	}
+	if missing := p.missingKeyField(inMsg, typeName, false); missing != "" {
+		p.warning(`stub will be generated for %s since %s incoming message doesn't have %q field`, methodName, p.TypeName(inType), missing)
+		return false, ""
+	}
 	return true, typeName
 }
 
@@ -544,10 +777,14 @@ func (p *OrmPlugin) generateDeleteSetServerMethod(service autogenService, method
 	if method.followsConvention {
 		typeName := method.baseType
 		p.generateDBSetup(service)
-		p.P(`objs := []*`, typeName, `{}`)
-		p.P(`for _, id := range in.Ids {`)
-		p.P(`objs = append(objs, &`, typeName, `{Id: id})`)
-		p.P(`}`)
+		if pkNames := p.primaryKeyFields(p.getOrmable(typeName)); len(pkNames) > 1 {
+			p.generateObjectsFromKeys(service, typeName, pkNames)
+		} else {
+			p.P(`objs := []*`, typeName, `{}`)
+			p.P(`for _, id := range in.Ids {`)
+			p.P(`objs = append(objs, &`, typeName, `{Id: id})`)
+			p.P(`}`)
+		}
 		p.generatePreserviceCall(service, method.baseType, method.ccName)
 		p.P(`err := DefaultDelete`, typeName, `Set(ctx, objs, db)`)
 		p.P(`if err != nil {`)
@@ -565,19 +802,28 @@ func (p *OrmPlugin) generateDeleteSetServerMethod(service autogenService, method
 	}
 }
 
+// generateObjectsFromKeys builds the objects to delete from the repeated
+// fields of the composite key, which must have as many values each
+func (p *OrmPlugin) generateObjectsFromKeys(service autogenService, typeName string, pkNames []string) {
+	var fields []string
+	first := `in.Get` + inflection.Plural(pkNames[0]) + `()`
+	for _, pkName := range pkNames[1:] {
+		p.P(`if len(in.Get`, inflection.Plural(pkName), `()) != len(`, first, `) {`)
+		p.P(`return nil, `, p.wrapSpanError(service, p.Import(gerrorsImport)+".EmptyIdError"))
+		p.P(`}`)
+	}
+	for _, pkName := range pkNames {
+		fields = append(fields, fmt.Sprint(pkName, `: in.Get`, inflection.Plural(pkName), `()[i]`))
+	}
+	p.P(`objs := make([]*`, typeName, `, 0, len(`, first, `))`)
+	p.P(`for i := range `, first, ` {`)
+	p.P(`objs = append(objs, &`, typeName, `{`, strings.Join(fields, ", "), `})`)
+	p.P(`}`)
+}
+
 func (p *OrmPlugin) followsDeleteSetConventions(inType generator.Object, outType generator.Object, method *descriptor.MethodDescriptorProto) (bool, string) {
 	inMsg := inType.(*generator.Descriptor)
 	methodName := generator.CamelCase(method.GetName())
-	var hasIDs bool
-	for _, field := range inMsg.Field {
-		if field.GetName() == "ids" && field.IsRepeated() {
-			hasIDs = true
-		}
-	}
-	if !hasIDs {
-		p.warning(`stub will be generated for %s since %s incoming message doesn't have "ids" field`, methodName, p.TypeName(inType))
-		return false, ""
-	}
 	typeName := generator.CamelCase(getMethodOptions(method).GetObjectType())
 	if typeName == "" {
 		p.warning(`stub will be generated for %s since (gorm.method).object_type option is not specified`, methodName)
@@ -591,6 +837,10 @@ func (p *OrmPlugin) followsDeleteSetConventions(inType generator.Object, outType
 		p.warning(`stub will be generated for %s since %s ormable type doesn't have a primary key`, methodName, typeName)
 		return false, ""
 	}
+	if missing := p.missingKeyField(inMsg, typeName, true); missing != "" {
+		p.warning(`stub will be generated for %s since %s incoming message doesn't have %q field`, methodName, p.TypeName(inType), missing)
+		return false, ""
+	}
 	return true, typeName
 }
 
@@ -617,14 +867,20 @@ func (p *OrmPlugin) generateListServerMethod(service autogenService, method auto
 		if fs := p.getFieldSelection(method.inType); fs != "" {
 			handlerCall += fmt.Sprint(",in.", fs)
 		}
+		handlerCall += p.withDeletedArg(method)
 		handlerCall += ")"
 		p.P(handlerCall)
+		p.generateInvalidFieldStatus(method.baseType)
 		p.P(`if err != nil {`)
 		p.P(`return nil, `, p.wrapSpanError(service, "err"))
 		p.P(`}`)
 		var pageInfoIfExist string
 		if pg != "" && pi != "" {
-			p.generatePagedRequestHandling(pg)
+			p.generatePagedRequestHandling(service, method, pg)
+			pageInfoIfExist = ", " + pi + ": resPaging"
+		}
+		if pi != "" && p.listHasCount(p.getOrmable(method.baseType)) && !getMethodOptions(method.MethodDescriptorProto).GetSkipCount() {
+			p.generateCountHandling(service, method, pg != "")
 			pageInfoIfExist = ", " + pi + ": resPaging"
 		}
 		p.P(`out := &`, p.TypeName(method.outType), `{Results: res`, pageInfoIfExist, ` }`)
@@ -659,7 +915,85 @@ func (p *OrmPlugin) followsListConventions(inType generator.Object, outType gene
 	return true, outTypeName
 }
 
+// generateListStreamServerMethod generates a server-streaming List method
+// sending each row read by DefaultStreamList<Type> in the result field of a
+// response
+func (p *OrmPlugin) generateListStreamServerMethod(service autogenService, method autogenMethod) {
+	p.generateStreamMethodSignature(service, method)
+	if method.followsConvention {
+		p.P(`ctx := stream.Context()`)
+		p.generateDBSetupReturning(service, "")
+		p.generatePreserviceCallReturning(service, method.baseType, method.ccName, "")
+		handlerCall := fmt.Sprint(`err := DefaultStreamList`, method.baseType, `(ctx, db`)
+		if f := p.getFiltering(method.inType); f != "" {
+			handlerCall += fmt.Sprint(",in.", f)
+		}
+		if s := p.getSorting(method.inType); s != "" {
+			handlerCall += fmt.Sprint(",in.", s)
+		}
+		if pg := p.getPagination(method.inType); pg != "" {
+			handlerCall += fmt.Sprint(",in.", pg)
+		}
+		if fs := p.getFieldSelection(method.inType); fs != "" {
+			handlerCall += fmt.Sprint(",in.", fs)
+		}
+		handlerCall += p.withDeletedArg(method)
+		handlerCall += fmt.Sprint(`, func(res *`, method.baseType, `) error {`)
+		p.P(handlerCall)
+		p.P(`return stream.Send(&`, p.TypeName(method.outType), `{Result: res})`)
+		p.P(`})`)
+		p.generateInvalidFieldStatus(method.baseType)
+		p.P(`if err != nil {`)
+		p.P(`return `, p.wrapSpanError(service, "err"))
+		p.P(`}`)
+		p.P(`return nil`)
+		p.P(`}`)
+		p.generatePreserviceHook(service.ccName, method.baseType, method.ccName)
+	} else {
+		p.P(`return nil`)
+		p.P(`}`)
+	}
+}
+
+func (p *OrmPlugin) followsListStreamConventions(inType generator.Object, outType generator.Object, methodName string) (bool, string) {
+	outMsg := outType.(*generator.Descriptor)
+	for _, field := range outMsg.Field {
+		if field.GetName() == "result" {
+			gType, _ := p.GoType(outMsg, field)
+			if typeName := strings.TrimPrefix(gType, "*"); p.isOrmable(typeName) {
+				return true, typeName
+			}
+		}
+	}
+	p.warning(`stub will be generated for %s since %s outgoing message doesn't have "result" field of ormable type`, methodName, p.TypeName(outType))
+	return false, ""
+}
+
+// generateStreamMethodSignature generates the signature of a server-streaming
+// method, which gets its context from the stream
+func (p *OrmPlugin) generateStreamMethodSignature(service autogenService, method autogenMethod) {
+	p.P(`// `, method.ccName, ` ...`)
+	p.P(`func (m *`, service.GetName(), `DefaultServer) `, method.ccName, ` (in *`,
+		p.TypeName(method.inType), `, stream `, service.ccName, `_`, method.ccName, `Server) error {`)
+	p.RecordTypeUse(method.GetInputType())
+	p.RecordTypeUse(method.GetOutputType())
+	withSpan := getServiceOptions(service.ServiceDescriptorProto).WithTracing
+	if withSpan {
+		p.P(`span, errSpanCreate := m.spanCreate(stream.Context(), in, "`, method.ccName, `")`)
+		p.P(`if errSpanCreate != nil {`)
+		p.P(`return errSpanCreate`)
+		p.P(`}`)
+		p.P(`defer span.End()`)
+	}
+}
+
 func (p *OrmPlugin) generateMethodStub(service autogenService, method autogenMethod) {
+	if method.GetServerStreaming() {
+		p.generateStreamMethodSignature(service, method)
+		p.P(`return nil`)
+		p.P(`}`)
+		return
+	}
 	p.generateMethodSignature(service, method)
 	p.generateEmptyBody(service, method.outType)
 }
@@ -681,14 +1015,20 @@ func (p *OrmPlugin) generateMethodSignature(service autogenService, method autog
 }
 
 func (p *OrmPlugin) generateDBSetup(service autogenService) error {
+	return p.generateDBSetupReturning(service, "nil, ")
+}
+
+// generateDBSetupReturning is generateDBSetup for methods returning ret
+// before the error, streaming methods only return the error
+func (p *OrmPlugin) generateDBSetupReturning(service autogenService, ret string) error {
 	if service.usesTxnMiddleware {
 		p.P(`txn, ok := `, p.Import(tkgormImport), `.FromContext(ctx)`)
 		p.P(`if !ok {`)
-		p.P(`return nil, `, p.Import(gerrorsImport), `.NoTransactionError`)
+		p.P(`return `, ret, p.wrapStatusError(service, p.Import(gerrorsImport)+`.NoTransactionError`))
 		p.P(`}`)
 		p.P(`db := txn.Begin()`)
 		p.P(`if db.Error != nil {`)
-		p.P(`return nil, db.Error`)
+		p.P(`return `, ret, p.wrapStatusError(service, `db.Error`))
 		p.P(`}`)
 	} else {
 		p.P(`db := m.DB`)
@@ -721,10 +1061,14 @@ func (p *OrmPlugin) getMethodProps(method *descriptor.MethodDescriptorProto) (ge
 }
 
 func (p *OrmPlugin) generatePreserviceCall(service autogenService, typeName, mthd string) {
+	p.generatePreserviceCallReturning(service, typeName, mthd, "nil, ")
+}
+
+func (p *OrmPlugin) generatePreserviceCallReturning(service autogenService, typeName, mthd, ret string) {
 	p.P(`if custom, ok := interface{}(in).(`, service.ccName, typeName, `WithBefore`, mthd, `); ok {`)
 	p.P(`var err error`)
 	p.P(`if db, err = custom.Before`, mthd, `(ctx, db); err != nil {`)
-	p.P(`return nil, `, p.wrapSpanError(service, "err"))
+	p.P(`return `, ret, p.wrapSpanError(service, "err"))
 	p.P(`}`)
 	p.P(`}`)
 }
@@ -737,18 +1081,88 @@ func (p *OrmPlugin) generatePagedRequestSetup(pg string) {
 	p.P(`}`)
 }
 
-func (p *OrmPlugin) generatePagedRequestHandling(pg string) {
+func (p *OrmPlugin) generatePagedRequestHandling(service autogenService, method autogenMethod, pg string) {
+	keyset := p.listHasKeysetPagination(p.getOrmable(method.baseType))
 	p.P(fmt.Sprintf(`var resPaging *%s.PageInfo`, p.Import(queryImport)))
 	p.P(`if pagedRequest {`)
 	p.P(`var offset int32`)
 	p.P(`var size int32 = int32(len(res))`)
+	if keyset {
+		p.P(`more := false`)
+	}
 	p.P(fmt.Sprintf(`if size == in.Get%s().GetLimit(){`, pg))
 	p.P(`size--`)
 	p.P(`res=res[:size]`)
 	p.P(fmt.Sprintf(`offset=in.Get%s().GetOffset()+size`, pg))
+	if keyset {
+		p.P(`more = true`)
+	}
 	p.P(`}`)
-	p.P(fmt.Sprintf(`resPaging = &%s.PageInfo{Offset: offset}`, p.Import(queryImport)))
+	if keyset {
+		sorting := "nil"
+		if s := p.getSorting(method.inType); s != "" {
+			sorting = fmt.Sprint("in.Get", s, "()")
+		}
+		p.P(fmt.Sprintf(`resPaging = &%s.PageInfo{Offset: offset}`, p.Import(queryImport)))
+		// an empty page token requests the first page, which also returns the
+		// token of the next one unless the sorting cannot be held by a token
+		p.P(fmt.Sprintf(`if in.Get%s().GetPageToken() != "" || in.Get%s().GetOffset() == 0 {`, pg, pg))
+		p.P(`token, tokenErr := `, p.Import(paginationImport), `.FirstPageToken, error(nil)`)
+		p.P(`if more {`)
+		p.P(`token, tokenErr = Default`, method.baseType, `PageToken(ctx, res[size-1], `, sorting, `)`)
+		p.P(`}`)
+		p.P(`switch {`)
+		p.P(fmt.Sprintf(`case in.Get%s().GetPageToken() != "":`, pg))
+		p.P(`if tokenErr != nil {`)
+		p.P(`return nil, `, p.wrapSpanError(service, "tokenErr"))
+		p.P(`}`)
+		p.P(fmt.Sprintf(`resPaging = &%s.PageInfo{PageToken: token}`, p.Import(queryImport)))
+		p.P(`case tokenErr == nil:`)
+		p.P(`resPaging.PageToken = token`)
+		p.P(`}`)
+		p.P(`}`)
+		// without a limit all the rows are returned, the last page
+		p.P(fmt.Sprintf(`} else if in.Get%s().GetPageToken() != "" {`, pg))
+		p.P(fmt.Sprintf(`resPaging = &%s.PageInfo{PageToken: %s.FirstPageToken}`, p.Import(queryImport), p.Import(paginationImport)))
+	} else {
+		p.P(fmt.Sprintf(`resPaging = &%s.PageInfo{Offset: offset}`, p.Import(queryImport)))
+	}
+	p.P(`}`)
+}
+
+// generateCountHandling sets the size of the PageInfo of a List response to
+// the number of rows matching the filter
+func (p *OrmPlugin) generateCountHandling(service autogenService, method autogenMethod, paged bool) {
+	countCall := fmt.Sprint(`total, err := DefaultCount`, method.baseType, `(ctx, db`)
+	if f := p.getFiltering(method.inType); f != "" {
+		countCall += fmt.Sprint(",in.", f)
+	}
+	countCall += p.withDeletedArg(method)
+	countCall += ")"
+	p.P(countCall)
+	p.P(`if err != nil {`)
+	p.P(`return nil, `, p.wrapSpanError(service, "err"))
 	p.P(`}`)
+	if paged {
+		p.P(`if resPaging == nil {`)
+		p.P(`resPaging = &`, p.Import(queryImport), `.PageInfo{}`)
+		p.P(`}`)
+	} else {
+		p.P(`resPaging := &`, p.Import(queryImport), `.PageInfo{}`)
+	}
+	p.P(`resPaging.Size = int32(total)`)
+}
+
+// withDeletedArg returns the withDeleted argument of the List and Count
+// handlers of soft deleted types
+func (p *OrmPlugin) withDeletedArg(method autogenMethod) string {
+	if !p.getOrmable(method.baseType).SoftDelete {
+		return ""
+	}
+	if wd := p.getWithDeleted(method.inType); wd != "" {
+		return fmt.Sprint(",in.Get", wd, "()")
+	}
+	return ",false"
 }
 
 func (p *OrmPlugin) generatePreserviceHook(svc, typeName, mthd string) {
@@ -790,6 +1204,18 @@ func (p *OrmPlugin) getPagination(object generator.Object) string {
 	return p.getFieldOfType(object, "Pagination")
 }
 
+// getWithDeleted returns the name of the with_deleted bool field of a List
+// request, which includes the soft deleted rows in the response
+func (p *OrmPlugin) getWithDeleted(object generator.Object) string {
+	msg := object.(*generator.Descriptor)
+	for _, field := range msg.Field {
+		if field.GetName() == "with_deleted" && field.GetType() == descriptor.FieldDescriptorProto_TYPE_BOOL {
+			return generator.CamelCase(field.GetName())
+		}
+	}
+	return ""
+}
+
 func (p *OrmPlugin) getPageInfo(object generator.Object) string {
 	return p.getFieldOfType(object, "PageInfo")
 }
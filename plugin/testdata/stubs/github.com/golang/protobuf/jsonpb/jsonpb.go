@@ -0,0 +1,25 @@
+// Package jsonpb is a stub of the github.com/golang/protobuf jsonpb package
+package jsonpb
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type Marshaler struct {
+	OrigName     bool
+	EnumsAsInts  bool
+	EmitDefaults bool
+	Indent       string
+}
+
+func (m *Marshaler) Marshal(w io.Writer, pb proto.Message) error { return nil }
+
+func (m *Marshaler) MarshalToString(pb proto.Message) (string, error) { return "", nil }
+
+type Unmarshaler struct {
+	AllowUnknownFields bool
+}
+
+func (u *Unmarshaler) Unmarshal(r io.Reader, pb proto.Message) error { return nil }
@@ -0,0 +1,14 @@
+// Package proto is a stub of the github.com/golang/protobuf proto package
+package proto
+
+type Message interface {
+	Reset()
+	String() string
+	ProtoMessage()
+}
+
+func Marshal(m Message) ([]byte, error) { return nil, nil }
+
+func Unmarshal(b []byte, m Message) error { return nil }
+
+func Clone(src Message) Message { return src }
@@ -0,0 +1,11 @@
+// Package any is a stub of the Any well-known type
+package any
+
+type Any struct {
+	TypeUrl string
+	Value   []byte
+}
+
+func (m *Any) Reset()         {}
+func (m *Any) String() string { return "" }
+func (*Any) ProtoMessage()    {}
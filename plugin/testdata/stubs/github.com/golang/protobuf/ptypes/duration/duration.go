@@ -0,0 +1,11 @@
+// Package duration is a stub of the Duration well-known type
+package duration
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (m *Duration) Reset()         {}
+func (m *Duration) String() string { return "" }
+func (*Duration) ProtoMessage()    {}
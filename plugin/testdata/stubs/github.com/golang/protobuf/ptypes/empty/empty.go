@@ -0,0 +1,9 @@
+// Package empty is a stub of the Empty well-known type
+package empty
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()         {}
+func (m *Empty) String() string { return "" }
+func (*Empty) ProtoMessage()    {}
@@ -0,0 +1,17 @@
+// Package ptypes is a stub of the github.com/golang/protobuf ptypes package
+package ptypes
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+func Timestamp(ts *timestamp.Timestamp) (time.Time, error) { return time.Time{}, nil }
+
+func TimestampProto(t time.Time) (*timestamp.Timestamp, error) { return nil, nil }
+
+func Duration(d *duration.Duration) (time.Duration, error) { return 0, nil }
+
+func DurationProto(d time.Duration) *duration.Duration { return nil }
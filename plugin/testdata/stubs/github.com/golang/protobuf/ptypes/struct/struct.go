@@ -0,0 +1,28 @@
+// Package structpb is a stub of the Struct well-known types
+package structpb
+
+type Struct struct {
+	Fields map[string]*Value
+}
+
+func (m *Struct) Reset()         {}
+func (m *Struct) String() string { return "" }
+func (*Struct) ProtoMessage()    {}
+
+type Value struct {
+	Kind isValue_Kind
+}
+
+func (m *Value) Reset()         {}
+func (m *Value) String() string { return "" }
+func (*Value) ProtoMessage()    {}
+
+type ListValue struct {
+	Values []*Value
+}
+
+func (m *ListValue) Reset()         {}
+func (m *ListValue) String() string { return "" }
+func (*ListValue) ProtoMessage()    {}
+
+type isValue_Kind interface{ isValue_Kind() }
@@ -0,0 +1,11 @@
+// Package timestamp is a stub of the Timestamp well-known type
+package timestamp
+
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (m *Timestamp) Reset()         {}
+func (m *Timestamp) String() string { return "" }
+func (*Timestamp) ProtoMessage()    {}
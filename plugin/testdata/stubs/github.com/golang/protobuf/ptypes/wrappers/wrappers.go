@@ -0,0 +1,42 @@
+// Package wrappers is a stub of the wrappers well-known types
+package wrappers
+
+type StringValue struct {
+	Value string
+}
+
+func (m *StringValue) Reset()         {}
+func (m *StringValue) String() string { return "" }
+func (*StringValue) ProtoMessage()    {}
+
+type BytesValue struct {
+	Value []byte
+}
+
+func (m *BytesValue) Reset()         {}
+func (m *BytesValue) String() string { return "" }
+func (*BytesValue) ProtoMessage()    {}
+
+type Int64Value struct {
+	Value int64
+}
+
+func (m *Int64Value) Reset()         {}
+func (m *Int64Value) String() string { return "" }
+func (*Int64Value) ProtoMessage()    {}
+
+type UInt32Value struct {
+	Value uint32
+}
+
+func (m *UInt32Value) Reset()         {}
+func (m *UInt32Value) String() string { return "" }
+func (*UInt32Value) ProtoMessage()    {}
+
+type BoolValue struct {
+	Value bool
+}
+
+func (m *BoolValue) Reset()         {}
+func (m *BoolValue) String() string { return "" }
+func (*BoolValue) ProtoMessage()    {}
@@ -0,0 +1,26 @@
+// Package gorm is a stub of the atlas-app-toolkit GORM helpers
+package gorm
+
+import (
+	"context"
+
+	"github.com/infobloxopen/atlas-app-toolkit/query"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+func ApplyCollectionOperators(ctx context.Context, db *gorm.DB, obj interface{}, pb interface{}, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, error) {
+	return db, nil
+}
+
+func ApplyFieldSelection(ctx context.Context, db *gorm.DB, fs *query.FieldSelection, obj interface{}) (*gorm.DB, error) {
+	return db, nil
+}
+
+func MergeWithMask(source, dest interface{}, mask *field_mask.FieldMask) error { return nil }
+
+type Transaction struct{}
+
+func (t *Transaction) Begin() *gorm.DB { return nil }
+
+func FromContext(ctx context.Context) (*Transaction, bool) { return nil, false }
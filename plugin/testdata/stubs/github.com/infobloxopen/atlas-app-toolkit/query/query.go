@@ -0,0 +1,84 @@
+// Package query is a stub of the atlas-app-toolkit collection operators
+package query
+
+type Filtering struct{}
+
+func (*Filtering) Reset()         {}
+func (*Filtering) String() string { return "" }
+func (*Filtering) ProtoMessage()  {}
+
+type SortCriteria_Order int32
+
+const (
+	SortCriteria_ASC  SortCriteria_Order = 0
+	SortCriteria_DESC SortCriteria_Order = 1
+)
+
+type SortCriteria struct {
+	Tag   string
+	Order SortCriteria_Order
+}
+
+func (m *SortCriteria) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *SortCriteria) GetOrder() SortCriteria_Order {
+	if m != nil {
+		return m.Order
+	}
+	return SortCriteria_ASC
+}
+
+type Sorting struct {
+	Criterias []*SortCriteria
+}
+
+func (*Sorting) Reset()         {}
+func (*Sorting) String() string { return "" }
+func (*Sorting) ProtoMessage()  {}
+
+func (m *Sorting) GetCriterias() []*SortCriteria {
+	if m != nil {
+		return m.Criterias
+	}
+	return nil
+}
+
+type FieldSelection struct{}
+
+type Pagination struct {
+	PageToken string
+	Offset    int32
+	Limit     int32
+}
+
+func (m *Pagination) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *Pagination) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Pagination) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PageInfo struct {
+	PageToken string
+	Size      int32
+	Offset    int32
+}
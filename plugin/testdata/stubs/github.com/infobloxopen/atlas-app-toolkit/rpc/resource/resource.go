@@ -0,0 +1,12 @@
+// Package resource is a stub of the atlas-app-toolkit resource identifier
+package resource
+
+type Identifier struct {
+	ApplicationName string
+	ResourceType    string
+	ResourceId      string
+}
+
+func (m *Identifier) Reset()         {}
+func (m *Identifier) String() string { return "" }
+func (*Identifier) ProtoMessage()    {}
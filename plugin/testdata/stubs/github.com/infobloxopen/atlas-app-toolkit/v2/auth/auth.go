@@ -0,0 +1,6 @@
+// Package auth is a stub of the atlas-app-toolkit authorization helpers
+package auth
+
+import "context"
+
+func GetAccountID(ctx context.Context, keyfunc interface{}) (string, error) { return "", nil }
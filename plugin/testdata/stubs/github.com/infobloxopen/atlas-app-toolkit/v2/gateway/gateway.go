@@ -0,0 +1,6 @@
+// Package gateway is a stub of the atlas-app-toolkit gateway helpers
+package gateway
+
+import "context"
+
+func SetCreated(ctx context.Context, msg string) error { return nil }
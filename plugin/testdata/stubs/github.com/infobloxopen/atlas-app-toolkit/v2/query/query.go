@@ -0,0 +1,20 @@
+// Package query is a stub of the atlas-app-toolkit v2 collection operators,
+// which share the messages of the v1 ones
+package query
+
+import q "github.com/infobloxopen/atlas-app-toolkit/query"
+
+type (
+	Filtering          = q.Filtering
+	SortCriteria_Order = q.SortCriteria_Order
+	SortCriteria       = q.SortCriteria
+	Sorting            = q.Sorting
+	FieldSelection     = q.FieldSelection
+	Pagination         = q.Pagination
+	PageInfo           = q.PageInfo
+)
+
+const (
+	SortCriteria_ASC  = q.SortCriteria_ASC
+	SortCriteria_DESC = q.SortCriteria_DESC
+)
@@ -0,0 +1,19 @@
+// Package postgres is a stub of the Postgres types of GORM v1
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+type Hstore map[string]*string
+
+func (h Hstore) Value() (driver.Value, error)  { return nil, nil }
+func (h *Hstore) Scan(value interface{}) error { return nil }
+
+type Jsonb struct {
+	json.RawMessage
+}
+
+func (j Jsonb) Value() (driver.Value, error)  { return nil, nil }
+func (j *Jsonb) Scan(value interface{}) error { return nil }
@@ -0,0 +1,24 @@
+// Package pq is a stub of the github.com/lib/pq array types
+package pq
+
+import "database/sql/driver"
+
+type BoolArray []bool
+
+func (a *BoolArray) Scan(src interface{}) error  { return nil }
+func (a BoolArray) Value() (driver.Value, error) { return nil, nil }
+
+type Float64Array []float64
+
+func (a *Float64Array) Scan(src interface{}) error  { return nil }
+func (a Float64Array) Value() (driver.Value, error) { return nil, nil }
+
+type Int64Array []int64
+
+func (a *Int64Array) Scan(src interface{}) error  { return nil }
+func (a Int64Array) Value() (driver.Value, error) { return nil, nil }
+
+type StringArray []string
+
+func (a *StringArray) Scan(src interface{}) error  { return nil }
+func (a StringArray) Value() (driver.Value, error) { return nil, nil }
@@ -0,0 +1,22 @@
+// Package uuid is a stub of github.com/satori/go.uuid
+package uuid
+
+import "database/sql/driver"
+
+type UUID [16]byte
+
+var Nil = UUID{}
+
+func (u UUID) Bytes() []byte { return u[:] }
+
+func (u UUID) String() string { return "" }
+
+func (u UUID) Value() (driver.Value, error) { return u.String(), nil }
+
+func (u *UUID) Scan(src interface{}) error { return nil }
+
+func FromString(input string) (UUID, error) { return Nil, nil }
+
+func FromBytes(input []byte) (UUID, error) { return Nil, nil }
+
+func NewV4() UUID { return Nil }
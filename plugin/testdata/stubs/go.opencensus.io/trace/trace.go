@@ -0,0 +1,41 @@
+// Package trace is a stub of the OpenCensus tracing API
+package trace
+
+import "context"
+
+type Span struct{}
+
+func (s *Span) End() {}
+
+func (s *Span) AddAttributes(attributes ...Attribute) {}
+
+func (s *Span) Annotate(attributes []Attribute, str string) {}
+
+func (s *Span) SetStatus(status Status) {}
+
+type Attribute struct {
+	key   string
+	value interface{}
+}
+
+func StringAttribute(key string, value string) Attribute { return Attribute{key: key, value: value} }
+
+func BoolAttribute(key string, value bool) Attribute { return Attribute{key: key, value: value} }
+
+type Status struct {
+	Code    int32
+	Message string
+}
+
+const (
+	StatusCodeOK      = 0
+	StatusCodeUnknown = 2
+)
+
+type StartOption func(*StartOptions)
+
+type StartOptions struct{}
+
+func StartSpan(ctx context.Context, name string, o ...StartOption) (context.Context, *Span) {
+	return ctx, nil
+}
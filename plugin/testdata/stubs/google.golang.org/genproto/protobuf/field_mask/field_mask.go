@@ -0,0 +1,17 @@
+// Package field_mask is a stub of the FieldMask well-known type
+package field_mask
+
+type FieldMask struct {
+	Paths []string
+}
+
+func (m *FieldMask) Reset()         {}
+func (m *FieldMask) String() string { return "" }
+func (*FieldMask) ProtoMessage()    {}
+
+func (m *FieldMask) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
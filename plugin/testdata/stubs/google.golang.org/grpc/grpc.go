@@ -0,0 +1,66 @@
+// Package grpc is a stub of the gRPC API used by the protoc-gen-gogo services
+package grpc
+
+import "context"
+
+const SupportPackageIsVersion4 = true
+
+type CallOption interface{}
+
+type ClientConn struct{}
+
+func (cc *ClientConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...CallOption) error {
+	return nil
+}
+
+func (cc *ClientConn) NewStream(ctx context.Context, desc *StreamDesc, method string, opts ...CallOption) (ClientStream, error) {
+	return nil, nil
+}
+
+type ClientStream interface {
+	CloseSend() error
+	Context() context.Context
+	SendMsg(m interface{}) error
+	RecvMsg(m interface{}) error
+}
+
+type ServerStream interface {
+	Context() context.Context
+	SendMsg(m interface{}) error
+	RecvMsg(m interface{}) error
+}
+
+type UnaryServerInfo struct {
+	Server     interface{}
+	FullMethod string
+}
+
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+type UnaryServerInterceptor func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (resp interface{}, err error)
+
+type StreamHandler func(srv interface{}, stream ServerStream) error
+
+type MethodDesc struct {
+	MethodName string
+	Handler    func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor UnaryServerInterceptor) (interface{}, error)
+}
+
+type StreamDesc struct {
+	StreamName    string
+	Handler       StreamHandler
+	ServerStreams bool
+	ClientStreams bool
+}
+
+type ServiceDesc struct {
+	ServiceName string
+	HandlerType interface{}
+	Methods     []MethodDesc
+	Streams     []StreamDesc
+	Metadata    interface{}
+}
+
+type Server struct{}
+
+func (s *Server) RegisterService(sd *ServiceDesc, ss interface{}) {}
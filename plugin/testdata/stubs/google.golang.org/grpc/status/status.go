@@ -0,0 +1,22 @@
+// Package status is a stub of the gRPC status errors
+package status
+
+import "google.golang.org/grpc/codes"
+
+type Status struct{}
+
+func (s *Status) Code() codes.Code { return codes.OK }
+
+func (s *Status) Message() string { return "" }
+
+func (s *Status) Err() error { return nil }
+
+func New(c codes.Code, msg string) *Status { return nil }
+
+func Error(c codes.Code, msg string) error { return nil }
+
+func Errorf(c codes.Code, format string, a ...interface{}) error { return nil }
+
+func FromError(err error) (*Status, bool) { return nil, false }
+
+func Code(err error) codes.Code { return codes.OK }
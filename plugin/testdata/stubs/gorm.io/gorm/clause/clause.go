@@ -0,0 +1,56 @@
+// Package clause is a stub of the gorm.io/gorm SQL clauses
+package clause
+
+const Associations = "~~~as~~~"
+
+type Builder interface{}
+
+type Expression interface {
+	Build(builder Builder)
+}
+
+type Column struct {
+	Table string
+	Name  string
+	Alias string
+	Raw   bool
+}
+
+type Table struct {
+	Name  string
+	Alias string
+	Raw   bool
+}
+
+type Where struct {
+	Exprs []Expression
+}
+
+type Assignment struct {
+	Column Column
+	Value  interface{}
+}
+
+type Set []Assignment
+
+func AssignmentColumns(values []string) Set { return nil }
+
+type Locking struct {
+	Strength string
+	Table    Table
+	Options  string
+}
+
+func (locking Locking) Build(builder Builder) {}
+
+type OnConflict struct {
+	Columns      []Column
+	Where        Where
+	TargetWhere  Where
+	OnConstraint string
+	DoNothing    bool
+	DoUpdates    Set
+	UpdateAll    bool
+}
+
+func (onConflict OnConflict) Build(builder Builder) {}
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+	gorm "github.com/suutaku/protoc-gen-gorm/options"
+)
+
+const (
+	protoTypeDuration  = "Duration"
+	protoTypeStruct    = "Struct"
+	protoTypeValue     = "Value"
+	protoTypeListValue = "ListValue"
+	protoTypeAny       = "Any"
+	protoTypeFieldMask = "FieldMask"
+)
+
+// serializedWellKnownTypes are the formats of the well-known types stored as
+// serialized messages, an Any keeps its type URL and bytes
+var serializedWellKnownTypes = map[string]gorm.SerializeFormat{
+	protoTypeStruct:    gorm.SerializeFormat_JSON,
+	protoTypeValue:     gorm.SerializeFormat_JSON,
+	protoTypeListValue: gorm.SerializeFormat_JSON,
+	protoTypeAny:       gorm.SerializeFormat_PROTO_BINARY,
+}
+
+// wellKnownMessage returns the name of the google.protobuf message type of a
+// field, empty for the other fields
+func wellKnownMessage(field *descriptor.FieldDescriptorProto) string {
+	if !field.IsMessage() || !strings.HasPrefix(field.GetTypeName(), ".google.protobuf.") {
+		return ""
+	}
+	return strings.TrimPrefix(field.GetTypeName(), ".google.protobuf.")
+}
+
+// durationType returns the ORM type and its package of a Duration field, a
+// time.Duration in a bigint column holding nanoseconds, or a types.Interval
+// for the interval type tag on Postgres
+func (p *OrmPlugin) durationType(field *descriptor.FieldDescriptorProto) (string, string) {
+	if strings.EqualFold(getFieldOptions(field).GetTag().GetType(), "interval") && p.dbEngine == ENGINE_POSTGRES {
+		return fmt.Sprintf("*%s.Interval", p.Import(gtypesImport)), gtypesImport
+	}
+	p.UsingGoImports(stdTimeImport)
+	return "*time.Duration", stdTimeImport
+}
+
+// fieldMaskType returns the ORM type, its package and the column type of a
+// FieldMask field, its paths are stored in a text array on Postgres and as a
+// JSON array otherwise
+func (p *OrmPlugin) fieldMaskType() (string, string, string) {
+	if p.dbEngine == ENGINE_POSTGRES {
+		return fmt.Sprintf("%s.StringArray", p.Import(pqImport)), pqImport, "text[]"
+	}
+	columnType := "text"
+	if p.dbEngine == ENGINE_MYSQL {
+		columnType = "json"
+	}
+	return fmt.Sprintf("%s.JSONStringArray", p.Import(gtypesImport)), gtypesImport, columnType
+}
+
+// generateDurationConversion converts a Duration field to or from its ORM type
+func (p *OrmPlugin) generateDurationConversion(field *descriptor.FieldDescriptorProto, ormType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	interval := strings.HasSuffix(ormType, ".Interval")
+	p.P(`if m.`, fieldName, ` != nil {`)
+	if toORM {
+		p.P(`d, err := `, p.Import(ptypesImport), `.Duration(m.`, fieldName, `)`)
+		p.P(`if err != nil {`)
+		p.P(`return to, err`)
+		p.P(`}`)
+		if interval {
+			p.P(`v := `, strings.TrimPrefix(ormType, "*"), `(d)`)
+			p.P(`to.`, fieldName, ` = &v`)
+		} else {
+			p.P(`to.`, fieldName, ` = &d`)
+		}
+	} else if interval {
+		p.UsingGoImports(stdTimeImport)
+		p.P(`to.`, fieldName, ` = `, p.Import(ptypesImport), `.DurationProto(time.Duration(*m.`, fieldName, `))`)
+	} else {
+		p.P(`to.`, fieldName, ` = `, p.Import(ptypesImport), `.DurationProto(*m.`, fieldName, `)`)
+	}
+	p.P(`}`)
+}
+
+// generateFieldMaskConversion converts a FieldMask field to or from its ORM
+// type, a nil mask is a NULL column and an empty one an empty array
+func (p *OrmPlugin) generateFieldMaskConversion(field *descriptor.FieldDescriptorProto, fieldType, ormType string, toORM bool) {
+	fieldName := generator.CamelCase(field.GetName())
+	p.P(`if m.`, fieldName, ` != nil {`)
+	if toORM {
+		p.P(`to.`, fieldName, ` = append(`, ormType, `{}, m.`, fieldName, `.Paths...)`)
+	} else {
+		p.P(`to.`, fieldName, ` = &`, strings.TrimPrefix(fieldType, "*"), `{Paths: append([]string{}, m.`, fieldName, `...)}`)
+	}
+	p.P(`}`)
+}
@@ -0,0 +1,47 @@
+// Package schema describes the tables of the ORM types at runtime, the
+// generated code declares one Table per ormable message
+package schema
+
+// Table describes the table of an ORM type
+type Table struct {
+	Name    string
+	Columns []Column
+	// FieldPaths maps the paths of the proto fields to their columns
+	FieldPaths map[string]string
+}
+
+// Column describes a column of an ORM type
+type Column struct {
+	// Field is the name of the ORM struct field
+	Field string
+	Name  string
+	// Type is the SQL type of the column on the engine the code was
+	// generated for
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	// Indexes and UniqueIndexes are the names of the indexes including the
+	// column
+	Indexes       []string
+	UniqueIndexes []string
+}
+
+// Column returns the column of the given name, nil if there is none
+func (t *Table) Column(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// FieldColumn returns the column of a proto field path, nil if the field
+// has no column
+func (t *Table) FieldColumn(path string) *Column {
+	name, ok := t.FieldPaths[path]
+	if !ok {
+		return nil
+	}
+	return t.Column(name)
+}
@@ -0,0 +1,23 @@
+package schema
+
+import "testing"
+
+func TestTable(t *testing.T) {
+	table := Table{
+		Name:       "users",
+		Columns:    []Column{{Field: "Id", Name: "id", PrimaryKey: true}, {Field: "Email", Name: "email_address", UniqueIndexes: []string{"idx_email"}}},
+		FieldPaths: map[string]string{"id": "id", "email": "email_address"},
+	}
+	if c := table.Column("email_address"); c == nil || c.Field != "Email" {
+		t.Errorf("got %+v; want the Email column", c)
+	}
+	if c := table.FieldColumn("email"); c == nil || c.Name != "email_address" {
+		t.Errorf("got %+v; want the email_address column", c)
+	}
+	if c := table.FieldColumn("password"); c != nil {
+		t.Errorf("got %+v; want nil", c)
+	}
+	if c := table.Column("password"); c != nil {
+		t.Errorf("got %+v; want nil", c)
+	}
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+)
+
+// BinaryInet is a scannable IP address stored in its 4 or 16 byte binary form,
+// the layout of the MySQL INET6_ATON function, so it fits a varbinary(16)
+// column. The netmask is not stored, a scanned address is a single host
+type BinaryInet struct {
+	*net.IPNet
+}
+
+// Value implements the Value part of the sql scannable interface
+func (i BinaryInet) Value() (driver.Value, error) {
+	if i.IPNet == nil {
+		return nil, nil
+	}
+	if v4 := i.IP.To4(); v4 != nil {
+		return []byte(v4), nil
+	}
+	return []byte(i.IP.To16()), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (i *BinaryInet) Scan(value interface{}) error {
+	if value == nil {
+		i.IPNet = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("Could not cast value in BinaryInet.Scan as []byte")
+	}
+	switch len(bytes) {
+	case net.IPv4len:
+		i.IPNet = &net.IPNet{IP: net.IP(append([]byte{}, bytes...)), Mask: net.CIDRMask(32, 32)}
+	case net.IPv6len:
+		i.IPNet = &net.IPNet{IP: net.IP(append([]byte{}, bytes...)), Mask: net.CIDRMask(128, 128)}
+	default:
+		return errors.New("Invalid length of value in BinaryInet.Scan")
+	}
+	return nil
+}
+
+// ParseBinaryInet will return the BinaryInet address represented in the input string
+func ParseBinaryInet(addr string) (*BinaryInet, error) {
+	inet, err := ParseInet(addr)
+	if inet == nil || err != nil {
+		return nil, err
+	}
+	return &BinaryInet{inet.IPNet}, nil
+}
+
+func (i *BinaryInet) String() string {
+	return (&Inet{i.IPNet}).String()
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"net"
+	"testing"
+)
+
+func TestBinaryInetScan(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		len   int
+		want  string
+	}{
+		{"v4", "192.168.1.1", 4, "192.168.1.1"},
+		{"v4 with mask", "192.168.1.1/24", 4, "192.168.1.1"},
+		{"v6", "2001:0db8:85a3:0000:0000:8a2e:0370:7334", 16, "2001:db8:85a3::8a2e:370:7334"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inet, _ := ParseBinaryInet(tc.input)
+			v, _ := inet.Value()
+			if b, ok := v.([]byte); !ok || len(b) != tc.len {
+				t.Fatalf("got %v; want %d bytes", v, tc.len)
+			}
+			var scanned BinaryInet
+			if err := scanned.Scan(v); err != nil {
+				t.Fatal(err)
+			}
+			if got := scanned.String(); got != tc.want {
+				t.Errorf("got %s; want %s", got, tc.want)
+			}
+			if !scanned.IP.Equal(net.ParseIP(tc.want)) {
+				t.Errorf("got %v; want %s", scanned.IP, tc.want)
+			}
+		})
+	}
+	var i BinaryInet
+	testScanErrors(t, &i, []byte{1, 2, 3}, "192.168.1.1")
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// BinaryUUID is a scannable UUID stored as its 16 raw bytes, so it fits a
+// binary(16) column instead of the 36 characters of the text form
+type BinaryUUID [16]byte
+
+// BinaryUUIDFromString parses the canonical text form of a UUID, with or
+// without hyphens and optionally wrapped in braces or prefixed by urn:uuid:
+func BinaryUUIDFromString(s string) (BinaryUUID, error) {
+	var u BinaryUUID
+	if len(s) == 45 && s[:9] == "urn:uuid:" {
+		s = s[9:]
+	} else if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	var digits []byte
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("uuid: incorrect UUID format %s", s)
+		}
+		digits = []byte(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	case 32:
+		digits = []byte(s)
+	default:
+		return u, fmt.Errorf("uuid: incorrect UUID length: %s", s)
+	}
+	if _, err := hex.Decode(u[:], digits); err != nil {
+		return u, fmt.Errorf("uuid: incorrect UUID format %s", s)
+	}
+	return u, nil
+}
+
+// String returns the canonical text form of the UUID
+func (u BinaryUUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// Value implements the Value part of the sql scannable interface
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (u *BinaryUUID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*u = BinaryUUID{}
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.Scan(string(v))
+	case string:
+		parsed, err := BinaryUUIDFromString(v)
+		if err != nil {
+			return err
+		}
+		*u = parsed
+	default:
+		return errors.New("Could not cast value in BinaryUUID.Scan as []byte or string")
+	}
+	return nil
+}
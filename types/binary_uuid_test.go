@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestBinaryUUIDFromString(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for _, input := range []string{
+		canonical,
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		u, err := BinaryUUIDFromString(input)
+		if err != nil {
+			t.Errorf("failed to parse %s: %v", input, err)
+		} else if got := u.String(); got != canonical {
+			t.Errorf("got %s; want %s", got, canonical)
+		}
+	}
+	for _, input := range []string{"", "6ba7b810", "6ba7b810x9dad-11d1-80b4-00c04fd430c8", "zba7b810-9dad-11d1-80b4-00c04fd430c8"} {
+		if _, err := BinaryUUIDFromString(input); err == nil {
+			t.Errorf("expected error parsing %q", input)
+		}
+	}
+}
+
+func TestBinaryUUIDScan(t *testing.T) {
+	u, _ := BinaryUUIDFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	v, _ := u.Value()
+	if b, ok := v.([]byte); !ok || len(b) != 16 {
+		t.Fatalf("got %v; want 16 bytes", v)
+	}
+	for _, input := range []interface{}{u.String(), []byte(u.String())} {
+		var scanned BinaryUUID
+		if err := scanned.Scan(input); err != nil {
+			t.Errorf("failed to scan %v: %v", input, err)
+		} else if scanned != u {
+			t.Errorf("got %s; want %s", scanned, u)
+		}
+	}
+	var scanned BinaryUUID
+	testScanErrors(t, &scanned, 42, []byte{1, 2, 3}, "6ba7b810")
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Hstore is a scannable map[string]string stored in a Postgres hstore column,
+// NULL values are read as empty strings
+type Hstore map[string]string
+
+// Value implements the Value part of the sql scannable interface
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf []byte
+	for i, k := range keys {
+		if i > 0 {
+			buf = append(buf, ", "...)
+		}
+		buf = appendHstoreString(buf, k)
+		buf = append(buf, "=>"...)
+		buf = appendHstoreString(buf, h[k])
+	}
+	return string(buf), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (h *Hstore) Scan(value interface{}) error {
+	var src []byte
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		src = v
+	case string:
+		src = []byte(v)
+	default:
+		return errors.New("Could not cast value in Hstore Scan as []byte or string")
+	}
+	res := Hstore{}
+	for i := skipHstoreSpace(src, 0); i < len(src); i = skipHstoreSpace(src, i) {
+		if len(res) > 0 {
+			if src[i] != ',' {
+				return fmt.Errorf("invalid hstore %q", src)
+			}
+			i = skipHstoreSpace(src, i+1)
+		}
+		key, n, err := scanHstoreString(src, i)
+		if err != nil {
+			return err
+		}
+		i = skipHstoreSpace(src, n)
+		if i+1 >= len(src) || src[i] != '=' || src[i+1] != '>' {
+			return fmt.Errorf("invalid hstore %q", src)
+		}
+		i = skipHstoreSpace(src, i+2)
+		if len(src) >= i+4 && string(src[i:i+4]) == "NULL" {
+			res[key] = ""
+			i += 4
+			continue
+		}
+		val, n, err := scanHstoreString(src, i)
+		if err != nil {
+			return err
+		}
+		res[key] = val
+		i = n
+	}
+	*h = res
+	return nil
+}
+
+func appendHstoreString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf = append(buf, '\\')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
+
+// scanHstoreString reads the double quoted string starting at i, and returns
+// it with the index following it
+func scanHstoreString(src []byte, i int) (string, int, error) {
+	if i >= len(src) || src[i] != '"' {
+		return "", i, fmt.Errorf("invalid hstore %q", src)
+	}
+	var s []byte
+	for i++; i < len(src) && src[i] != '"'; i++ {
+		if src[i] == '\\' {
+			i++
+		}
+		if i < len(src) {
+			s = append(s, src[i])
+		}
+	}
+	if i >= len(src) {
+		return "", i, fmt.Errorf("unterminated string in hstore %q", src)
+	}
+	return string(s), i + 1, nil
+}
+
+func skipHstoreSpace(src []byte, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n') {
+		i++
+	}
+	return i
+}
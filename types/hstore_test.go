@@ -0,0 +1,15 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHstoreScan(t *testing.T) {
+	var h Hstore
+	if err := h.Scan([]byte(`"a"=>"1", "b"=>NULL, "say \"hi\""=>"c:\\"`)); err != nil ||
+		!reflect.DeepEqual(h, Hstore{"a": "1", "b": "", `say "hi"`: `c:\`}) {
+		t.Errorf("got %v, %v", h, err)
+	}
+	testScanErrors(t, &h, `"a"`, `"a"=>"1" "b"=>"2"`, `"a=>"1"`, `a=>b`, 1)
+}
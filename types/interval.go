@@ -0,0 +1,105 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is a scannable time.Duration stored in a Postgres interval column,
+// with the microsecond precision of the column. It reads the default
+// postgres IntervalStyle, where like in Postgres a month is 30 days and a year
+// 365.25 days, and the microseconds written by Value. NULL is a zero Interval
+type Interval time.Duration
+
+// Value implements the Value part of the sql scannable interface
+func (i Interval) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d microseconds", time.Duration(i)/time.Microsecond), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (i *Interval) Scan(value interface{}) error {
+	var src string
+	switch v := value.(type) {
+	case nil:
+		*i = 0
+		return nil
+	case []byte:
+		src = string(v)
+	case string:
+		src = v
+	default:
+		return errors.New("Could not cast value in Interval Scan as []byte or string")
+	}
+	var months, days int64
+	var d time.Duration
+	fields := strings.Fields(src)
+	for n := 0; n < len(fields); n++ {
+		if strings.Contains(fields[n], ":") {
+			t, err := parseIntervalTime(fields[n])
+			if err != nil {
+				return err
+			}
+			d += t
+			continue
+		}
+		v, err := strconv.ParseInt(fields[n], 10, 64)
+		if err != nil || n+1 == len(fields) {
+			return fmt.Errorf("invalid interval %q", src)
+		}
+		n++
+		switch fields[n] {
+		case "year", "years":
+			months += 12 * v
+		case "mon", "mons":
+			months += v
+		case "day", "days":
+			days += v
+		case "microsecond", "microseconds":
+			d += time.Duration(v) * time.Microsecond
+		default:
+			return fmt.Errorf("invalid interval %q", src)
+		}
+	}
+	d += time.Duration(months/12)*8766*time.Hour + time.Duration(months%12*30+days)*24*time.Hour
+	*i = Interval(d)
+	return nil
+}
+
+// parseIntervalTime parses the [-]hh:mm:ss[.ffffff] part of an interval
+func parseIntervalTime(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	parts := strings.Split(strings.TrimLeft(s, "+-"), ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid interval time %q", s)
+	}
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval time %q", s)
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval time %q", s)
+	}
+	sec, frac := parts[2], ""
+	if dot := strings.Index(sec, "."); dot >= 0 {
+		sec, frac = sec[:dot], sec[dot+1:]
+	}
+	secs, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil || len(frac) > 9 {
+		return 0, fmt.Errorf("invalid interval time %q", s)
+	}
+	var nanos int64
+	if frac != "" {
+		if nanos, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid interval time %q", s)
+		}
+	}
+	return sign * (time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(secs)*time.Second + time.Duration(nanos)), nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntervalScan(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Duration
+	}{
+		{"00:00:00", 0},
+		{"01:30:00.000001", 90*time.Minute + time.Microsecond},
+		{"-00:00:01.5", -1500 * time.Millisecond},
+		{"-00:00:00.000001", -time.Microsecond},
+		{"2562047:47:16.854775", 2562047*time.Hour + 47*time.Minute + 16854775*time.Microsecond},
+		{"1 day", 24 * time.Hour},
+		{"-1 days +02:03:00", -22*time.Hour + 3*time.Minute},
+		{"-1 days -02:03:04.5", -26*time.Hour - 3*time.Minute - 4500*time.Millisecond},
+		{"1 day -00:00:00.25", 24*time.Hour - 250*time.Millisecond},
+		{"-1 mons +1 day", -29 * 24 * time.Hour},
+		{"1 year 2 mons 3 days 04:05:06", (8766+63*24+4)*time.Hour + 5*time.Minute + 6*time.Second},
+		{"-1500000 microseconds", -1500 * time.Millisecond},
+	} {
+		var i Interval
+		if err := i.Scan([]byte(tc.in)); err != nil || time.Duration(i) != tc.want {
+			t.Errorf("%q: got %v, %v; want %v", tc.in, time.Duration(i), err, tc.want)
+		}
+	}
+	i := Interval(time.Hour)
+	if err := i.Scan(nil); err != nil || i != 0 {
+		t.Errorf("scanned NULL into %v, %v", time.Duration(i), err)
+	}
+	testScanErrors(t, &i, "1", "1 week", "P1D", "01:02", "1:x:00", "00:00:00.1234567891", "1.5 days", 1)
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// The JSON arrays are scannable repeated scalars stored as a JSON document, for
+// the DB engines without native array columns
+
+// JSONBoolArray is a []bool stored as a JSON array
+type JSONBoolArray []bool
+
+// JSONFloat64Array is a []float64 stored as a JSON array
+type JSONFloat64Array []float64
+
+// JSONInt64Array is a []int64 stored as a JSON array
+type JSONInt64Array []int64
+
+// JSONStringArray is a []string stored as a JSON array
+type JSONStringArray []string
+
+// JSONInt32Array is a []int32 stored as a JSON array
+type JSONInt32Array []int32
+
+// JSONUint32Array is a []uint32 stored as a JSON array
+type JSONUint32Array []uint32
+
+// JSONUint64Array is a []uint64 stored as a JSON array
+type JSONUint64Array []uint64
+
+// JSONFloat32Array is a []float32 stored as a JSON array
+type JSONFloat32Array []float32
+
+// JSONBytesArray is a [][]byte stored as a JSON array of base64 strings
+type JSONBytesArray [][]byte
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONBoolArray) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONBoolArray) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONFloat64Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONFloat64Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONInt64Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONInt64Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONStringArray) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONStringArray) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONInt32Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONInt32Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONUint32Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONUint32Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONUint64Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONUint64Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONFloat32Array) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONFloat32Array) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// Value implements the Value part of the sql scannable interface
+func (a JSONBytesArray) Value() (driver.Value, error) { return jsonArrayValue(a, a == nil) }
+
+// Scan implements the scan part of the sql scannable interface
+func (a *JSONBytesArray) Scan(value interface{}) error { return jsonArrayScan(value, a) }
+
+// jsonArrayValue returns the JSON document of the array as a string, which
+// MySQL accepts for JSON columns and SQLite stores as text, unlike []byte
+func jsonArrayValue(a interface{}, isNil bool) (driver.Value, error) {
+	if isNil {
+		return nil, nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func jsonArrayScan(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return json.Unmarshal([]byte("null"), dest)
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	}
+	return errors.New("Could not cast value in JSON array Scan as []byte or string")
+}
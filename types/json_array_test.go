@@ -0,0 +1,28 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONArrayScan(t *testing.T) {
+	var strs JSONStringArray
+	if err := strs.Scan(`["a\"b",null]`); err != nil || !reflect.DeepEqual(strs, JSONStringArray{`a"b`, ""}) {
+		t.Errorf("got %v, %v", strs, err)
+	}
+	var ints JSONInt64Array
+	if err := ints.Scan(`[1,2]`); err != nil || !reflect.DeepEqual(ints, JSONInt64Array{1, 2}) {
+		t.Errorf("got %v, %v", ints, err)
+	}
+	if err := ints.Scan(`null`); err != nil || ints != nil {
+		t.Errorf("got %v, %v", ints, err)
+	}
+	var blobs JSONBytesArray
+	if err := blobs.Scan([]byte(`["aGk="]`)); err != nil || !reflect.DeepEqual(blobs, JSONBytesArray{[]byte("hi")}) {
+		t.Errorf("got %v, %v", blobs, err)
+	}
+	var bools JSONBoolArray
+	testScanErrors(t, &bools, 1, `[1]`, `{"a":true}`, `[true`)
+	var uints JSONUint32Array
+	testScanErrors(t, &uints, `[-1]`, `[4294967296]`)
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// JSONText is a scannable type for a JSON document stored in a text column,
+// for the DB engines without a JSON column type. As the DB does not check the
+// document, it is validated before being written
+type JSONText struct {
+	json.RawMessage
+}
+
+// Value implements the Value part of the sql scannable interface
+func (j JSONText) Value() (driver.Value, error) {
+	if len(j.RawMessage) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(j.RawMessage) {
+		return nil, errors.New("JSONText.Value: invalid JSON document")
+	}
+	return string(j.RawMessage), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (j *JSONText) Scan(value interface{}) error {
+	if value == nil {
+		j.RawMessage = nil
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		j.RawMessage = append(json.RawMessage{}, v...)
+	case string:
+		j.RawMessage = json.RawMessage(v)
+	default:
+		return errors.New("Could not cast value in JSONText.Scan as []byte or string")
+	}
+	return nil
+}
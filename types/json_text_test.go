@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+)
+
+// TestJSONDocumentScan scans the Jsonb and JSONText documents, which only
+// differ in JSONText checking the document it stores
+func TestJSONDocumentScan(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		dest valueScanner
+	}{
+		{"jsonb", &Jsonb{}},
+		{"json text", &JSONText{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, input := range []interface{}{[]byte(`{"a":1}`), `{"a":1}`} {
+				if err := tc.dest.Scan(input); err != nil {
+					t.Errorf("scanning %v: %v", input, err)
+				} else if v, _ := tc.dest.Value(); v != `{"a":1}` {
+					t.Errorf("scanned %v into %v", input, v)
+				}
+			}
+			testScanErrors(t, tc.dest, 1)
+		})
+	}
+}
+
+func TestJSONTextInvalid(t *testing.T) {
+	if _, err := (JSONText{[]byte(`{"a":`)}).Value(); err == nil {
+		t.Error("expected error for invalid JSON document")
+	}
+}
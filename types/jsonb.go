@@ -0,0 +1,39 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Jsonb is a special scannable type for a JSON document, it replaces the
+// jinzhu/gorm postgres dialect type when generating GORM v2 code
+type Jsonb struct {
+	json.RawMessage
+}
+
+// Value implements the Value part of the sql scannable interface. The
+// document is a string, as MySQL rejects []byte for JSON columns
+func (j Jsonb) Value() (driver.Value, error) {
+	if len(j.RawMessage) == 0 {
+		return nil, nil
+	}
+	return string(j.RawMessage), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (j *Jsonb) Scan(value interface{}) error {
+	if value == nil {
+		j.RawMessage = nil
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		j.RawMessage = append(json.RawMessage{}, v...)
+	case string:
+		j.RawMessage = json.RawMessage(v)
+	default:
+		return errors.New("Could not cast value in Jsonb.Scan as []byte or string")
+	}
+	return nil
+}
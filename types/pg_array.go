@@ -0,0 +1,351 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The PG arrays are scannable repeated values stored in native Postgres array
+// columns, for the element types github.com/lib/pq has no array type for
+
+// PGInt32Array is a []int32 stored in an integer[] column
+type PGInt32Array []int32
+
+// PGUint32Array is a []uint32 stored in a bigint[] column, as integer cannot
+// hold all of its values
+type PGUint32Array []uint32
+
+// PGFloat32Array is a []float32 stored in a real[] column
+type PGFloat32Array []float32
+
+// PGBytesArray is a [][]byte stored in a bytea[] column
+type PGBytesArray [][]byte
+
+// PGUUIDArray is a []BinaryUUID stored in a uuid[] column
+type PGUUIDArray []BinaryUUID
+
+// PGTimeArray is a []time.Time stored in a timestamptz[] column
+type PGTimeArray []time.Time
+
+// Value implements the Value part of the sql scannable interface
+func (a PGInt32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		elems[i] = strconv.AppendInt(nil, int64(v), 10)
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (a *PGInt32Array) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGInt32Array, len(elems))
+	for i, elem := range elems {
+		v, err := strconv.ParseInt(string(elem), 10, 32)
+		if err != nil {
+			return fmt.Errorf("PGInt32Array.Scan: %v", err)
+		}
+		res[i] = int32(v)
+	}
+	*a = res
+	return nil
+}
+
+// Value implements the Value part of the sql scannable interface
+func (a PGUint32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		elems[i] = strconv.AppendUint(nil, uint64(v), 10)
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (a *PGUint32Array) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGUint32Array, len(elems))
+	for i, elem := range elems {
+		v, err := strconv.ParseUint(string(elem), 10, 32)
+		if err != nil {
+			return fmt.Errorf("PGUint32Array.Scan: %v", err)
+		}
+		res[i] = uint32(v)
+	}
+	*a = res
+	return nil
+}
+
+// Value implements the Value part of the sql scannable interface
+func (a PGFloat32Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		switch {
+		case math.IsInf(float64(v), 1):
+			elems[i] = []byte("Infinity")
+		case math.IsInf(float64(v), -1):
+			elems[i] = []byte("-Infinity")
+		default:
+			elems[i] = strconv.AppendFloat(nil, float64(v), 'g', -1, 32)
+		}
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (a *PGFloat32Array) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGFloat32Array, len(elems))
+	for i, elem := range elems {
+		// ParseFloat knows NaN, Infinity and -Infinity
+		v, err := strconv.ParseFloat(string(elem), 32)
+		if err != nil {
+			return fmt.Errorf("PGFloat32Array.Scan: %v", err)
+		}
+		res[i] = float32(v)
+	}
+	*a = res
+	return nil
+}
+
+// Value implements the Value part of the sql scannable interface
+func (a PGBytesArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		if v == nil {
+			continue
+		}
+		elems[i] = make([]byte, 2+hex.EncodedLen(len(v)))
+		copy(elems[i], `\x`)
+		hex.Encode(elems[i][2:], v)
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface, the values
+// must be in the default hex output format of bytea
+func (a *PGBytesArray) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGBytesArray, len(elems))
+	for i, elem := range elems {
+		if elem == nil {
+			continue
+		}
+		if !bytes.HasPrefix(elem, []byte(`\x`)) {
+			return errors.New("PGBytesArray.Scan: bytea value is not in hex format")
+		}
+		res[i] = make([]byte, hex.DecodedLen(len(elem)-2))
+		if _, err := hex.Decode(res[i], elem[2:]); err != nil {
+			return fmt.Errorf("PGBytesArray.Scan: %v", err)
+		}
+	}
+	*a = res
+	return nil
+}
+
+// Value implements the Value part of the sql scannable interface
+func (a PGUUIDArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		elems[i] = []byte(v.String())
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface
+func (a *PGUUIDArray) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGUUIDArray, len(elems))
+	for i, elem := range elems {
+		if res[i], err = BinaryUUIDFromString(string(elem)); err != nil {
+			return fmt.Errorf("PGUUIDArray.Scan: %v", err)
+		}
+	}
+	*a = res
+	return nil
+}
+
+const pgTimeFormat = "2006-01-02 15:04:05.999999999Z07:00"
+
+// Value implements the Value part of the sql scannable interface
+func (a PGTimeArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([][]byte, len(a))
+	for i, v := range a {
+		elems[i] = []byte(v.Format(pgTimeFormat))
+	}
+	return pgArrayValue(elems), nil
+}
+
+// Scan implements the scan part of the sql scannable interface, the offsets
+// of the values are formatted by Postgres as +hh, +hh:mm or +hh:mm:ss
+func (a *PGTimeArray) Scan(value interface{}) error {
+	elems, err := pgArrayScan(value)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	res := make(PGTimeArray, len(elems))
+	for i, elem := range elems {
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999Z07", "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999Z07:00:00"} {
+			if res[i], err = time.Parse(layout, string(elem)); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("PGTimeArray.Scan: %v", err)
+		}
+	}
+	*a = res
+	return nil
+}
+
+// pgArrayValue renders the text form of a one dimensional array, nil
+// elements are NULL
+func pgArrayValue(elems [][]byte) string {
+	buf := []byte{'{'}
+	for i, elem := range elems {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if elem == nil {
+			buf = append(buf, "NULL"...)
+			continue
+		}
+		buf = append(buf, '"')
+		for _, c := range elem {
+			if c == '"' || c == '\\' {
+				buf = append(buf, '\\')
+			}
+			buf = append(buf, c)
+		}
+		buf = append(buf, '"')
+	}
+	return string(append(buf, '}'))
+}
+
+// pgArrayScan splits the text form of a one dimensional array into its
+// elements, NULL elements are nil and so is the result for a NULL array
+func pgArrayScan(value interface{}) ([][]byte, error) {
+	var src []byte
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		src = v
+	case string:
+		src = []byte(v)
+	default:
+		return nil, errors.New("Could not cast value in Postgres array Scan as []byte or string")
+	}
+	// skip the bounds decoration of arrays not starting at index 1
+	if i := bytes.IndexByte(src, '='); i >= 0 && len(src) > 0 && src[0] == '[' {
+		src = src[i+1:]
+	}
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, fmt.Errorf("invalid Postgres array %q", src)
+	}
+	src = src[1 : len(src)-1]
+	elems := [][]byte{}
+	if len(src) == 0 {
+		return elems, nil
+	}
+	for i := 0; i <= len(src); i++ {
+		i = skipPGArraySpace(src, i)
+		var elem []byte
+		switch {
+		case i < len(src) && src[i] == '{':
+			return nil, errors.New("multidimensional Postgres arrays are not supported")
+		case i < len(src) && src[i] == '"':
+			elem = []byte{}
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+				if i < len(src) {
+					elem = append(elem, src[i])
+				}
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated element in Postgres array %q", src)
+			}
+			i = skipPGArraySpace(src, i+1)
+		default:
+			// unquoted elements may escape characters too, and are trimmed
+			escaped := false
+			for ; i < len(src) && src[i] != ','; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					escaped = true
+				} else if src[i] == '"' || src[i] == '{' || src[i] == '}' {
+					return nil, fmt.Errorf("invalid Postgres array %q", src)
+				}
+				elem = append(elem, src[i])
+			}
+			elem = bytes.TrimRight(elem, " \t\n\r\v\f")
+			if len(elem) == 0 {
+				return nil, fmt.Errorf("empty element in Postgres array %q", src)
+			}
+			if !escaped && bytes.EqualFold(elem, []byte("NULL")) {
+				elem = nil
+			}
+		}
+		if i < len(src) && src[i] != ',' {
+			return nil, fmt.Errorf("invalid Postgres array %q", src)
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// skipPGArraySpace returns the index of the first non whitespace character of
+// src from i on
+func skipPGArraySpace(src []byte, i int) int {
+	for i < len(src) && strings.IndexByte(" \t\n\r\v\f", src[i]) >= 0 {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestPGArrayQuoting scans elements holding the quotes, backslashes, braces,
+// commas and NULL words that are quoted or escaped in the text form
+func TestPGArrayQuoting(t *testing.T) {
+	elems := [][]byte{[]byte(`say "hi"`), []byte(`c:\`), []byte(`{x,y}`), []byte(`NULL`), nil, []byte(`\"`), {}, []byte(" a b ")}
+	value := pgArrayValue(elems)
+	if want := `{"say \"hi\"","c:\\","{x,y}","NULL",NULL,"\\\"",""," a b "}`; value != want {
+		t.Errorf("got %s; want %s", value, want)
+	}
+	got, err := pgArrayScan(value)
+	if err != nil || !reflect.DeepEqual(got, elems) {
+		t.Errorf("got %q, %v; want %q", got, err, elems)
+	}
+	got, err = pgArrayScan(`{ plain , "quoted, with \"{}\"" ,null,"",a\,b,\NULL}`)
+	if want := [][]byte{[]byte("plain"), []byte(`quoted, with "{}"`), nil, {}, []byte(`a,b`), []byte("NULL")}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, %v; want %q", got, err, want)
+	}
+	for _, bad := range []string{`{"unterminated}`, `{"a"b}`, `{a"b"}`, `{a,{b}}`, `{a,}`, `{a`, `a}`, ``} {
+		if _, err := pgArrayScan(bad); err == nil {
+			t.Errorf("expected error scanning %q", bad)
+		}
+	}
+}
+
+func TestPGArrayScan(t *testing.T) {
+	var ints PGInt32Array
+	if err := ints.Scan([]byte(`{1,-2,3}`)); err != nil || !reflect.DeepEqual(ints, PGInt32Array{1, -2, 3}) {
+		t.Errorf("got %v, %v", ints, err)
+	}
+	testScanErrors(t, &ints, `{{1,2},{3,4}}`, `{4294967295}`, `{1,NULL}`, 1)
+	var uints PGUint32Array
+	if err := uints.Scan(`[0:1]={4294967295,0}`); err != nil || !reflect.DeepEqual(uints, PGUint32Array{4294967295, 0}) {
+		t.Errorf("got %v, %v", uints, err)
+	}
+	var floats PGFloat32Array
+	if err := floats.Scan(`{0.5,Infinity,NaN}`); err != nil || len(floats) != 3 || floats[0] != 0.5 || !math.IsInf(float64(floats[1]), 1) || !math.IsNaN(float64(floats[2])) {
+		t.Errorf("got %v, %v", floats, err)
+	}
+	var blobs PGBytesArray
+	if err := blobs.Scan(`{"\\x6869",NULL,"\\x"}`); err != nil || !reflect.DeepEqual(blobs, PGBytesArray{[]byte("hi"), nil, {}}) {
+		t.Errorf("got %v, %v", blobs, err)
+	}
+	testScanErrors(t, &blobs, `{"hi"}`, `{"\\x6"}`)
+	var uuids PGUUIDArray
+	u, _ := BinaryUUIDFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if err := uuids.Scan(`{6ba7b810-9dad-11d1-80b4-00c04fd430c8}`); err != nil || !reflect.DeepEqual(uuids, PGUUIDArray{u}) {
+		t.Errorf("got %v, %v", uuids, err)
+	}
+	var times PGTimeArray
+	if err := times.Scan(`{"2020-01-02 03:04:05.6+00","2020-01-02 08:34:05+05:30"}`); err != nil || len(times) != 2 ||
+		!times[0].Equal(time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC)) || !times[1].Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("got %v, %v", times, err)
+	}
+	testScanErrors(t, &times, `{"yesterday"}`, 1)
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// valueScanner is a pointer to a type implementing the sql scannable interface
+type valueScanner interface {
+	driver.Valuer
+	sql.Scanner
+}
+
+// testRoundTrip checks that the Value of each value scans into a new value
+// with the same Value, also from the []byte form of a string, and that NULL
+// scans into the zero value over the scanned one
+func testRoundTrip(t *testing.T, values ...valueScanner) {
+	t.Helper()
+	for _, in := range values {
+		want, err := in.Value()
+		if err != nil {
+			t.Errorf("%v: %v", in, err)
+			continue
+		}
+		inputs := []interface{}{want}
+		if s, ok := want.(string); ok {
+			inputs = append(inputs, []byte(s))
+		}
+		for _, input := range inputs {
+			out := reflect.New(reflect.TypeOf(in).Elem()).Interface().(valueScanner)
+			if err := out.Scan(input); err != nil {
+				t.Errorf("scanning %#v: %v", input, err)
+				continue
+			}
+			if got, err := out.Value(); err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("scanned %#v into a value of %#v, %v; want %#v", input, got, err, want)
+			}
+			if err := out.Scan(nil); err != nil {
+				t.Errorf("scanning NULL: %v", err)
+			} else if zero := reflect.Zero(reflect.TypeOf(in).Elem()).Interface(); !reflect.DeepEqual(reflect.ValueOf(out).Elem().Interface(), zero) {
+				t.Errorf("scanned NULL into %v; want %v", out, zero)
+			}
+		}
+	}
+}
+
+// testScanErrors checks that scanning each of the inputs fails
+func testScanErrors(t *testing.T, dest sql.Scanner, inputs ...interface{}) {
+	t.Helper()
+	for _, input := range inputs {
+		if err := dest.Scan(input); err == nil {
+			t.Errorf("expected error scanning %#v into %T", input, dest)
+		}
+	}
+}
+
+// TestValue checks the text form the types are stored in
+func TestValue(t *testing.T) {
+	u, _ := BinaryUUIDFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, tc := range []struct {
+		name string
+		in   driver.Valuer
+		want driver.Value
+	}{
+		{"empty jsonb", Jsonb{}, nil},
+		{"jsonb", Jsonb{[]byte(`{"a":1}`)}, `{"a":1}`},
+		{"empty json text", JSONText{}, nil},
+		{"json text", JSONText{[]byte(`{"a":1}`)}, `{"a":1}`},
+		{"nil hstore", Hstore(nil), nil},
+		{"empty hstore", Hstore{}, ""},
+		{"sorted hstore", Hstore{"b": "2", "a": "1"}, `"a"=>"1", "b"=>"2"`},
+		{"escaped hstore", Hstore{`say "hi"`: `c:\`}, `"say \"hi\""=>"c:\\"`},
+		{"zero interval", Interval(0), "0 microseconds"},
+		{"interval", Interval(90*time.Minute + 1500*time.Nanosecond), "5400000001 microseconds"},
+		{"negative interval", Interval(-time.Second), "-1000000 microseconds"},
+		{"nil json strings", JSONStringArray(nil), nil},
+		{"json strings", JSONStringArray{"a", "b"}, `["a","b"]`},
+		{"empty json int64s", JSONInt64Array{}, `[]`},
+		{"json int64s", JSONInt64Array{1, -2}, `[1,-2]`},
+		{"json float64s", JSONFloat64Array{1.5}, `[1.5]`},
+		{"json bools", JSONBoolArray{true, false}, `[true,false]`},
+		{"json int32s", JSONInt32Array{3, -4}, `[3,-4]`},
+		{"json uint32s", JSONUint32Array{4294967295}, `[4294967295]`},
+		{"json uint64s", JSONUint64Array{18446744073709551615}, `[18446744073709551615]`},
+		{"json float32s", JSONFloat32Array{0.25}, `[0.25]`},
+		{"json bytes", JSONBytesArray{[]byte("hi"), nil}, `["aGk=",null]`},
+		{"nil pg int32s", PGInt32Array(nil), nil},
+		{"empty pg int32s", PGInt32Array{}, `{}`},
+		{"pg int32s", PGInt32Array{1, -2}, `{"1","-2"}`},
+		{"pg uint32s", PGUint32Array{4294967295}, `{"4294967295"}`},
+		{"pg float32s", PGFloat32Array{0.25, float32(math.Inf(-1))}, `{"0.25","-Infinity"}`},
+		{"pg bytes", PGBytesArray{[]byte("hi"), nil}, `{"\\x6869",NULL}`},
+		{"pg uuids", PGUUIDArray{u}, `{"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`},
+		{"pg times", PGTimeArray{time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC)}, `{"2020-01-02 03:04:05.6Z"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.in.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	u, _ := BinaryUUIDFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	intervals := []Interval{0, Interval(90*time.Minute + time.Microsecond), Interval(-26*time.Hour - 1500*time.Millisecond)}
+	testRoundTrip(t,
+		&Jsonb{}, &Jsonb{[]byte(`{"a":[1,"\"b\""],"c":null}`)}, &Jsonb{[]byte(`"text"`)},
+		&JSONText{}, &JSONText{[]byte(`{"a":[1,"\"b\""],"c":null}`)}, &JSONText{[]byte(`"text"`)},
+		&Hstore{}, &Hstore{"a": "1", `say "hi"`: `c:\`, "": "=>", "b, c": ""},
+		&intervals[0], &intervals[1], &intervals[2],
+		&JSONStringArray{}, &JSONStringArray{"a", `say "hi"`, `c:\`, ""},
+		&JSONInt64Array{1, -2}, &JSONFloat64Array{0.5, -1e300}, &JSONBoolArray{true, false},
+		&JSONInt32Array{-2147483648}, &JSONUint32Array{4294967295}, &JSONUint64Array{18446744073709551615},
+		&JSONFloat32Array{0.25}, &JSONBytesArray{[]byte("hi"), nil, {}},
+		&PGInt32Array{}, &PGInt32Array{1, -2147483648}, &PGUint32Array{4294967295, 0},
+		&PGFloat32Array{0.5, float32(math.Inf(1)), float32(math.NaN())},
+		&PGBytesArray{[]byte(`"{a,b}"\`), nil, {}}, &PGUUIDArray{u, {}},
+		&PGTimeArray{time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC)},
+		&u, &BinaryUUID{}, &BinaryInet{},
+	)
+	for _, addr := range []string{"192.168.1.1", "192.168.1.1/24", "::1", "2001:0db8:85a3:0000:0000:8a2e:0370:7334"} {
+		inet, err := ParseBinaryInet(addr)
+		if err != nil {
+			t.Fatalf("failed to parse BinaryInet value %s: %v", addr, err)
+		}
+		testRoundTrip(t, inet)
+	}
+}